@@ -31,6 +31,7 @@ const (
 	XGopenDeduplicationIDDegraded = "X-Gopen-Deduplication-Id-Degraded"
 	XGopenGroupIDDegraded         = "X-Gopen-Group-Id-Degraded"
 	XGopenAttributeDegraded       = "X-Gopen-Attribute-Degraded"
+	XGopenRoutingKeyDegraded      = "X-Gopen-Routing-Key-Degraded"
 	XGopenTimeout                 = "X-Gopen-Timeout"
 	XGopenCache                   = "X-Gopen-Cache"
 	XGopenCacheTTL                = "X-Gopen-Cache-Ttl"
@@ -40,6 +41,11 @@ const (
 	XGopenDegradedBackends        = "X-Gopen-Degraded-Backends"
 	XGopenComplete                = "X-Gopen-Complete"
 	XGopenSuccess                 = "X-Gopen-Success"
+	RateLimitLimit                = "RateLimit-Limit"
+	RateLimitRemaining            = "RateLimit-Remaining"
+	RateLimitReset                = "RateLimit-Reset"
+	RateLimitPolicy               = "RateLimit-Policy"
+	RetryAfter                    = "Retry-After"
 )
 
 func TransportHTTPHeaderKeys() []string {
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/app/usecase"
+	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+type adminController struct {
+	cacheUseCase usecase.Cache
+}
+
+type Admin interface {
+	PurgeCache(ctx app.Context)
+}
+
+func NewAdmin(cacheUseCase usecase.Cache) Admin {
+	return adminController{
+		cacheUseCase: cacheUseCase,
+	}
+}
+
+// PurgeCache deletes the cache entries informed by the repeatable query parameters key, the exact key, pattern, a
+// glob matching the keys, and tag, a tag carried by the entries.
+func (a adminController) PurgeCache(ctx app.Context) {
+	keys := ctx.Request().Query().GetAll("key")
+	patterns := ctx.Request().Query().GetAll("pattern")
+	tags := ctx.Request().Query().GetAll("tag")
+	if checker.IsEmpty(keys) && checker.IsEmpty(patterns) && checker.IsEmpty(tags) {
+		ctx.WriteError(enum.ResponseStatusInvalidArgument,
+			errors.Newf("admin purge cache requires 'key', 'pattern' or 'tag' query parameter"))
+		return
+	}
+
+	err := a.cacheUseCase.Purge(ctx.Context(), keys, patterns, tags)
+	if errors.Is(err, domain.ErrCachePurgeInvalid) {
+		ctx.WriteError(enum.ResponseStatusInvalidArgument, err)
+		return
+	} else if checker.NonNil(err) {
+		ctx.WriteError(enum.ResponseStatusInternalError, err)
+		return
+	}
+
+	ctx.WriteStatus(enum.ResponseStatusOK)
+}
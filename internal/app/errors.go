@@ -9,6 +9,8 @@ const (
 	codeErrBackendDependenciesNotExecuted = "BACKEND_DEPENDENCIES_NOT_EXECUTED"
 	codeErrBackendBrokerNotConfigured     = "BACKEND_BROKER_NOT_CONFIGURED"
 	codeErrBackendBrokerNotImplemented    = "BACKEND_BROKER_NOT_IMPLEMENTED"
+	codeErrAuthUnauthenticated            = "AUTH_UNAUTHENTICATED"
+	codeErrAuthPermissionDenied           = "AUTH_PERMISSION_DENIED"
 )
 const (
 	msgErrBackendConcurrentCancelled     = "backend failed: concurrent context cancelled"
@@ -17,6 +19,8 @@ const (
 	msgErrBackendGatewayTimeout          = "backend failed: gateway timeout err=%s"
 	msgErrBackendBrokerNotConfigured     = "backend failed: broker=%s not configured"
 	msgErrBackendBrokerNotImplemented    = "backend failed: broker=%s not implemented"
+	msgErrAuthUnauthenticated            = "auth failed: unauthenticated reason=%s"
+	msgErrAuthPermissionDenied           = "auth failed: permission denied missing scopes=%v"
 )
 
 var (
@@ -26,6 +30,8 @@ var (
 	ErrBackendBadGateway              = errors.TargetWithCode(codeErrBackendBadGateway)
 	ErrBackendGatewayTimeout          = errors.TargetWithCode(codeErrBackendGatewayTimeout)
 	ErrBackendConcurrentCancelled     = errors.TargetWithCode(codeErrBackendConcurrentCancelled)
+	ErrAuthUnauthenticated            = errors.TargetWithCode(codeErrAuthUnauthenticated)
+	ErrAuthPermissionDenied           = errors.TargetWithCode(codeErrAuthPermissionDenied)
 )
 
 func NewErrBackendConcurrentCancelled() error {
@@ -66,3 +72,11 @@ func NewErrBackendDependenciesNotExecuted(dependencies []string) error {
 		dependencies,
 	)
 }
+
+func NewErrAuthUnauthenticated(reason string) error {
+	return errors.NewWithSkipCallerAndCodef(2, codeErrAuthUnauthenticated, msgErrAuthUnauthenticated, reason)
+}
+
+func NewErrAuthPermissionDenied(missingScopes []string) error {
+	return errors.NewWithSkipCallerAndCodef(2, codeErrAuthPermissionDenied, msgErrAuthPermissionDenied, missingScopes)
+}
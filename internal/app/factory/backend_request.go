@@ -17,7 +17,10 @@
 package factory
 
 import (
+	"net/http"
+
 	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
 
 	"github.com/tech4works/gopen-gateway/internal/app"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/aggregate"
@@ -35,6 +38,12 @@ type BackendRequest interface {
 		*vo.HTTPBackendRequest, []error)
 	BuildPublisherRequest(backend *vo.BackendConfig, request *vo.EndpointRequest, history *aggregate.History) (
 		*vo.PublisherBackendRequest, []error)
+	BuildGRPCRequest(backend *vo.BackendConfig, request *vo.EndpointRequest, history *aggregate.History) (
+		*vo.GRPCBackendRequest, []error)
+	BuildGraphQLRequest(backend *vo.BackendConfig, request *vo.EndpointRequest, history *aggregate.History) (
+		*vo.HTTPBackendRequest, []error)
+	BuildWebSocketMessage(backend *vo.BackendConfig, message *vo.WebSocketMessage, request *vo.EndpointRequest,
+		history *aggregate.History) (*vo.WebSocketMessage, []error)
 }
 
 func NewBackendRequest(buildPipelineService service.BuildPipeline) BackendRequest {
@@ -88,6 +97,7 @@ func (f backendRequest) BuildHTTPRequest(
 		header,
 		query,
 		body,
+		backendHTTP.Signing(),
 	), joinErrs(headerErrs, urlPathErrs, queryErrs, bodyErrs)
 }
 
@@ -101,6 +111,8 @@ func (f backendRequest) BuildPublisherRequest(
 
 	groupID, groupIDDegraded, groupErrs := f.buildPublisherRequestGroupID(backendPublisher, request, history, useFallback)
 	dedupID, dedupDegraded, dedupErrs := f.buildPublisherRequestDedupID(backendPublisher, request, history, useFallback)
+	routingKey, routingKeyDegraded, routingKeyErrs := f.buildPublisherRequestRoutingKey(backendPublisher, request,
+		history, useFallback)
 	attrs, attrDegraded, attrErrs := f.buildPublisherRequestAttributes(backendPublisher.Message(), request, history,
 		useFallback)
 	body, bodyDegraded, bodyErrs := f.buildPublisherRequestBody(backendPublisher.Message().Body(), request, history,
@@ -113,6 +125,9 @@ func (f backendRequest) BuildPublisherRequest(
 	if dedupDegraded {
 		degradationKinds = append(degradationKinds, enum.DegradationKindDeduplicationID)
 	}
+	if routingKeyDegraded {
+		degradationKinds = append(degradationKinds, enum.DegradationKindRoutingKey)
+	}
 	if attrDegraded {
 		degradationKinds = append(degradationKinds, enum.DegradationKindAttributes)
 	}
@@ -126,10 +141,111 @@ func (f backendRequest) BuildPublisherRequest(
 		backendPublisher.Path(),
 		groupID,
 		dedupID,
+		routingKey,
 		backendPublisher.Delay(),
 		attrs,
 		body,
-	), joinErrs(groupErrs, dedupErrs, attrErrs, bodyErrs)
+	), joinErrs(groupErrs, dedupErrs, routingKeyErrs, attrErrs, bodyErrs)
+}
+
+// BuildGRPCRequest builds the metadata and the JSON payload as an HTTP request, the payload is only encoded into the
+// method input message by the client.
+func (f backendRequest) BuildGRPCRequest(
+	backend *vo.BackendConfig,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (*vo.GRPCBackendRequest, []error) {
+	backendGRPC := backend.GRPC()
+	useFallback := backend.Execution().UseFallback(enum.ExecutionOnBuild)
+
+	host := f.buildPipelineService.ApplyHost(backendGRPC)
+	payload, payloadDegraded, payloadErrs := f.buildHTTPRequestBody(backendGRPC.Request().Payload(), request, history,
+		useFallback)
+	metadata, metadataDegraded, metadataErrs := f.buildHTTPRequestHeader(backendGRPC.Request().Metadata(), request,
+		history, useFallback)
+
+	var degradationKinds []enum.DegradationKind
+	if payloadDegraded {
+		degradationKinds = append(degradationKinds, enum.DegradationKindPayload)
+	}
+	if metadataDegraded {
+		degradationKinds = append(degradationKinds, enum.DegradationKindMetadata)
+	}
+
+	return vo.NewGRPCBackendRequest(
+		vo.NewDegradation(degradationKinds...),
+		host,
+		backendGRPC.Service(),
+		backendGRPC.Method(),
+		backendGRPC.Descriptors(),
+		metadata,
+		payload,
+	), joinErrs(metadataErrs, payloadErrs)
+}
+
+// BuildGraphQLRequest builds the HTTP POST carrying the query document, the operation name and the variables
+// resolved from the dynamic values.
+func (f backendRequest) BuildGraphQLRequest(
+	backend *vo.BackendConfig,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (*vo.HTTPBackendRequest, []error) {
+	backendGraphQL := backend.GraphQL()
+	useFallback := backend.Execution().UseFallback(enum.ExecutionOnBuild)
+
+	host := f.buildPipelineService.ApplyHost(backendGraphQL)
+	variables, variablesDegraded, variablesErrs := f.buildGraphQLRequestVariables(backendGraphQL, request, history,
+		useFallback)
+	header, headerDegraded, headerErrs := f.buildHTTPRequestHeader(backendGraphQL.Header(), request, history,
+		useFallback)
+
+	var degradationKinds []enum.DegradationKind
+	if variablesDegraded {
+		degradationKinds = append(degradationKinds, enum.DegradationKindPayload)
+	}
+	if headerDegraded {
+		degradationKinds = append(degradationKinds, enum.DegradationKindMetadata)
+	}
+
+	document := map[string]any{
+		"query": backendGraphQL.Query(),
+	}
+	if backendGraphQL.HasOperationName() {
+		document["operationName"] = backendGraphQL.OperationName()
+	}
+	if checker.IsNotEmpty(variables) {
+		document["variables"] = variables
+	}
+
+	return vo.NewHTTPBackendRequest(
+		vo.NewDegradation(degradationKinds...),
+		host,
+		http.MethodPost,
+		vo.NewURLPath(backendGraphQL.Path(), request.Params().Copy()),
+		header,
+		vo.NewEmptyQuery(),
+		vo.NewPayloadJSON(converter.ToBuffer(document)),
+		nil,
+	), joinErrs(headerErrs, variablesErrs)
+}
+
+// BuildWebSocketMessage applies the backend request body transformations to a JSON text frame sent by the client,
+// other frames are forwarded untouched.
+func (f backendRequest) BuildWebSocketMessage(
+	backend *vo.BackendConfig,
+	message *vo.WebSocketMessage,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (*vo.WebSocketMessage, []error) {
+	spec := backend.HTTP().Request().Body()
+	if checker.IsNil(spec) || !message.IsJSON() {
+		return message, nil
+	}
+
+	payload, errs := f.buildPipelineService.ApplyPayload(spec, message.Payload(), request, history)
+	payload = fallbackIf(backend.Execution().UseFallback(enum.ExecutionOnBuild), errs, payload, message.Payload())
+
+	return vo.NewWebSocketMessageWithPayload(message.Type(), payload), errs
 }
 
 func (f backendRequest) buildHTTPRequestBody(
@@ -183,6 +299,16 @@ func (f backendRequest) buildHTTPRequestHeader(
 	return fallbackIf(useFallback, errs, header, request.Metadata()), isDegraded(spec, errs), errs
 }
 
+func (f backendRequest) buildGraphQLRequestVariables(
+	spec vo.VariablesPipelineSpec,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+	useFallback bool,
+) (map[string]any, bool, []error) {
+	variables, errs := f.buildPipelineService.ApplyVariables(spec, request, history)
+	return fallbackIf(useFallback, errs, variables, spec.Variables()), isDegraded(spec, errs), errs
+}
+
 func (f backendRequest) buildPublisherRequestGroupID(
 	spec vo.GroupIDPipelineSpec,
 	request *vo.EndpointRequest,
@@ -203,6 +329,16 @@ func (f backendRequest) buildPublisherRequestDedupID(
 	return deduplicationID, isDegraded(spec, errs), errs
 }
 
+func (f backendRequest) buildPublisherRequestRoutingKey(
+	spec vo.RoutingKeyPipelineSpec,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+	_ bool,
+) (string, bool, []error) {
+	routingKey, errs := f.buildPipelineService.ApplyRoutingKey(spec, request, history)
+	return routingKey, isDegraded(spec, errs), errs
+}
+
 func (f backendRequest) buildPublisherRequestAttributes(
 	spec vo.AttributesPipelineSpec,
 	request *vo.EndpointRequest,
@@ -19,10 +19,14 @@ package factory
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+
 	"github.com/tech4works/checker"
 	"github.com/tech4works/converter"
 	"github.com/tech4works/errors"
@@ -30,6 +34,7 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/app"
 	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
 	"github.com/tech4works/gopen-gateway/internal/app/model/publisher"
+	"github.com/tech4works/gopen-gateway/internal/app/model/rpc"
 	"github.com/tech4works/gopen-gateway/internal/domain"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/aggregate"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
@@ -50,12 +55,20 @@ type BackendResponse interface {
 	) *vo.BackendResponse
 	BuildResponseByHTTP(httpResponse *http.Response, duration time.Duration) *vo.BackendResponse
 	BuildResponseByPublisher(publisherResponse *publisher.Response, duration time.Duration) *vo.BackendResponse
+	BuildResponseByGRPC(grpcResponse *rpc.Response, duration time.Duration) *vo.BackendResponse
+	BuildResponseByGraphQL(httpResponse *http.Response, duration time.Duration) *vo.BackendResponse
 	BuildFinalResponse(
 		backend *vo.BackendConfig,
 		response *vo.BackendResponse,
 		request *vo.EndpointRequest,
 		history *aggregate.History,
 	) (*vo.BackendResponse, []error)
+	BuildWebSocketMessage(
+		backend *vo.BackendConfig,
+		message *vo.WebSocketMessage,
+		request *vo.EndpointRequest,
+		history *aggregate.History,
+	) (*vo.WebSocketMessage, []error)
 }
 
 func NewBackendResponse(buildPipelineService service.BuildPipeline) BackendResponse {
@@ -143,6 +156,68 @@ func (f backendResponse) BuildResponseByPublisher(publisherResponse *publisher.R
 	)
 }
 
+// BuildResponseByGRPC keeps the output message as the JSON body. When the call fails, the body holds the status code
+// and message returned by the backend.
+func (f backendResponse) BuildResponseByGRPC(grpcResponse *rpc.Response, duration time.Duration) *vo.BackendResponse {
+	status := vo.NewResponseStatus(enum.NewResponseStatusFromGRPC(grpcResponse.Code), int(grpcResponse.Code),
+		grpcResponse.Message)
+
+	var body *vo.Payload
+	if checker.NotEquals(grpcResponse.Code, codes.OK) {
+		body = vo.NewPayloadJSON(converter.ToBuffer(map[string]any{
+			"code":    grpcResponse.Code.String(),
+			"message": grpcResponse.Message,
+		}))
+	} else if checker.IsNotEmpty(grpcResponse.Body) {
+		body = vo.NewPayloadJSON(bytes.NewBuffer(grpcResponse.Body))
+	}
+
+	return vo.NewBackendResponse(
+		enum.BackendKindGRPC,
+		enum.BackendOutcomeExecuted,
+		duration,
+		status,
+		vo.NewMetadata(grpcResponse.Header),
+		body,
+	)
+}
+
+// BuildResponseByGraphQL unwraps the data of the GraphQL response into the body. When the errors array is filled,
+// the status is taken from the first error and the body keeps the whole response, data and errors, since a
+// GraphQL server answers with 200 even when the operation failed.
+func (f backendResponse) BuildResponseByGraphQL(httpResponse *http.Response, duration time.Duration,
+) *vo.BackendResponse {
+	response := f.BuildResponseByHTTP(httpResponse, duration)
+	if !response.HasBody() || !response.Payload().ContentType().IsJSON() {
+		return vo.NewBackendResponse(enum.BackendKindGraphQL, response.Outcome(), duration, response.Status(),
+			response.Metadata(), response.Payload())
+	}
+
+	bodyBytes, err := response.Payload().Bytes()
+	if checker.NonNil(err) {
+		panic(err)
+	}
+
+	var graphQLResponse dto.GraphQLResponse
+	if err = json.Unmarshal(bodyBytes, &graphQLResponse); checker.NonNil(err) {
+		return vo.NewBackendResponse(enum.BackendKindGraphQL, response.Outcome(), duration, response.Status(),
+			response.Metadata(), response.Payload())
+	}
+
+	if checker.IsNotEmpty(graphQLResponse.Errors) {
+		status := f.buildResponseStatusFromGraphQL(graphQLResponse.Errors[0])
+		return vo.NewBackendResponse(enum.BackendKindGraphQL, response.Outcome(), duration, status,
+			response.Metadata(), vo.NewPayloadJSON(bytes.NewBuffer(bodyBytes)))
+	}
+
+	var body *vo.Payload
+	if checker.IsNotEmpty(graphQLResponse.Data) && checker.NotEquals(string(graphQLResponse.Data), "null") {
+		body = vo.NewPayloadJSON(bytes.NewBuffer(graphQLResponse.Data))
+	}
+	return vo.NewBackendResponse(enum.BackendKindGraphQL, response.Outcome(), duration, response.Status(),
+		response.Metadata(), body)
+}
+
 func (f backendResponse) BuildFinalResponse(
 	backend *vo.BackendConfig,
 	response *vo.BackendResponse,
@@ -182,6 +257,25 @@ func (f backendResponse) BuildFinalResponse(
 	), joinErrs(payloadErrs, metadataErrs)
 }
 
+// BuildWebSocketMessage applies the backend response body transformations to a JSON text frame sent by the
+// backend, other frames are forwarded untouched.
+func (f backendResponse) BuildWebSocketMessage(
+	backend *vo.BackendConfig,
+	message *vo.WebSocketMessage,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (*vo.WebSocketMessage, []error) {
+	if !backend.HasResponse() || !backend.Response().HasPayload() || !message.IsJSON() {
+		return message, nil
+	}
+
+	payload, errs := f.buildPipelineService.ApplyPayload(backend.Response().Payload(), message.Payload(), request,
+		history)
+	payload = fallbackIf(backend.Execution().UseFallback(enum.ExecutionOnBuild), errs, payload, message.Payload())
+
+	return vo.NewWebSocketMessageWithPayload(message.Type(), payload), errs
+}
+
 func (f backendResponse) buildBackendOutcomeByError(err error) enum.BackendOutcome {
 	if errors.Is(err, app.ErrBackendDependenciesNotExecuted) {
 		return enum.BackendOutcomeCancelled
@@ -231,6 +325,36 @@ func (f backendResponse) buildFinalResponseMetadata(
 	return fallbackIf(useFallback, errs, metadata, response.Metadata()), isDegraded(spec, errs), errs
 }
 
+// buildResponseStatusFromGraphQL maps the extensions.code of the error, using the codes adopted by the most common
+// GraphQL servers. Errors without a known code are handled as internal errors of the backend.
+func (f backendResponse) buildResponseStatusFromGraphQL(graphQLError dto.GraphQLError) vo.ResponseStatus {
+	code, _ := graphQLError.Extensions["code"].(string)
+
+	var responseStatusEnum enum.ResponseStatus
+	switch strings.ToUpper(code) {
+	case "BAD_USER_INPUT", "BAD_REQUEST", "GRAPHQL_PARSE_FAILED", "GRAPHQL_VALIDATION_FAILED",
+		"OPERATION_RESOLUTION_FAILURE":
+		responseStatusEnum = enum.ResponseStatusInvalidArgument
+	case "UNAUTHENTICATED":
+		responseStatusEnum = enum.ResponseStatusUnauthenticated
+	case "FORBIDDEN":
+		responseStatusEnum = enum.ResponseStatusPermissionDenied
+	case "NOT_FOUND", "PERSISTED_QUERY_NOT_FOUND":
+		responseStatusEnum = enum.ResponseStatusNotFound
+	case "CONFLICT":
+		responseStatusEnum = enum.ResponseStatusConflict
+	case "TOO_MANY_REQUESTS", "RATE_LIMITED":
+		responseStatusEnum = enum.ResponseStatusResourceExhausted
+	case "PERSISTED_QUERY_NOT_SUPPORTED":
+		responseStatusEnum = enum.ResponseStatusUnimplemented
+	case "SERVICE_UNAVAILABLE":
+		responseStatusEnum = enum.ResponseStatusUnavailable
+	default:
+		responseStatusEnum = enum.ResponseStatusInternalError
+	}
+	return vo.NewResponseStatus(responseStatusEnum, code, graphQLError.Message)
+}
+
 func (f backendResponse) buildResponseStatusFromHTTP(code int) vo.ResponseStatus {
 	var responseStatusEnum enum.ResponseStatus
 
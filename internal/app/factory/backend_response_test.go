@@ -0,0 +1,75 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package factory
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/service"
+)
+
+func newGraphQLTestResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestBackendResponseBuildResponseByGraphQL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want enum.ResponseStatus
+		raw  string
+	}{
+		{"data", `{"data":{"user":{"id":1}}}`, enum.ResponseStatusOK, `{"user":{"id":1}}`},
+		{"bad user input", `{"data":null,"errors":[{"message":"invalid id","extensions":{"code":"BAD_USER_INPUT"}}]}`,
+			enum.ResponseStatusInvalidArgument, ""},
+		{"unauthenticated", `{"errors":[{"message":"no token","extensions":{"code":"UNAUTHENTICATED"}}]}`,
+			enum.ResponseStatusUnauthenticated, ""},
+		{"not found", `{"errors":[{"message":"missing","extensions":{"code":"not_found"}}]}`,
+			enum.ResponseStatusNotFound, ""},
+		{"first error wins", `{"errors":[{"message":"denied","extensions":{"code":"FORBIDDEN"}},{"message":"missing",` +
+			`"extensions":{"code":"NOT_FOUND"}}]}`, enum.ResponseStatusPermissionDenied, ""},
+		{"without code", `{"data":{"user":null},"errors":[{"message":"boom"}]}`, enum.ResponseStatusInternalError, ""},
+	}
+
+	f := NewBackendResponse(service.BuildPipeline{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := f.BuildResponseByGraphQL(newGraphQLTestResponse(tt.body), 0)
+			if got := response.Status().Value(); got != tt.want {
+				t.Errorf("BuildResponseByGraphQL() status = %v, want %v", got, tt.want)
+			} else if got := response.Status().OK(); got != (tt.want == enum.ResponseStatusOK) {
+				t.Errorf("BuildResponseByGraphQL() status OK = %v, want %v", got, tt.want == enum.ResponseStatusOK)
+			}
+
+			want := tt.raw
+			if want == "" {
+				want = tt.body
+			}
+			if got, err := response.Payload().CompactString(); err != nil || got != want {
+				t.Errorf("BuildResponseByGraphQL() body = %s, %v, want %s", got, err, want)
+			}
+		})
+	}
+}
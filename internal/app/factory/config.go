@@ -36,7 +36,65 @@ type propagateState struct {
 }
 
 func BuildGopen(gopen *dto.Gopen) *vo.GopenConfig {
-	return vo.NewGopenConfig(buildServer(gopen.Server), buildClient(gopen.Server), buildEndpoints(gopen))
+	endpoints := buildEndpoints(gopen)
+	return vo.NewGopenConfig(buildServer(gopen.Server), buildClient(gopen.Server), endpoints,
+		buildConsumers(gopen.Consumers, endpoints), buildAdmin(gopen))
+}
+
+// buildAdmin requires an auth for the administrative routes, the admin auth is merged with the root one as it is done
+// for the endpoints.
+func buildAdmin(gopen *dto.Gopen) *vo.AdminConfig {
+	if checker.IsNil(gopen.Admin) {
+		return nil
+	}
+
+	auth := buildAuth(gopen.Auth, gopen.Admin.Auth)
+	if checker.IsNil(auth) {
+		panic(errors.Newf("admin requires 'auth' property (either at root level or admin level)"))
+	}
+
+	return vo.NewAdminConfig(gopen.Admin.Path, auth)
+}
+
+// buildConsumers resolves the endpoint referenced by each consumer, which must be declared in endpoints and can not
+// be a WebSocket endpoint.
+func buildConsumers(consumers []dto.Consumer, endpoints []vo.EndpointConfig) []vo.ConsumerConfig {
+	var result []vo.ConsumerConfig
+	for _, consumer := range consumers {
+		endpoint := findConsumerEndpoint(consumer.Endpoint, endpoints)
+		if checker.IsNil(endpoint) {
+			panic(errors.Newf("consumer %s references an endpoint not declared: path=%s method=%s", consumer.Path,
+				consumer.Endpoint.Path, consumer.Endpoint.Method))
+		} else if endpoint.IsWebSocket() {
+			panic(errors.Newf("consumer %s references a websocket endpoint: path=%s", consumer.Path,
+				consumer.Endpoint.Path))
+		}
+
+		var maxAttempts int
+		var backoff vo.Duration
+		if checker.NonNil(consumer.Retry) {
+			if checker.NonNil(consumer.Retry.MaxAttempts) {
+				maxAttempts = *consumer.Retry.MaxAttempts
+			}
+			if checker.NonNil(consumer.Retry.Backoff) {
+				backoff = *consumer.Retry.Backoff
+			}
+		}
+
+		result = append(result, vo.NewConsumerConfig(consumer.ID, consumer.Broker, consumer.Path, consumer.Group,
+			endpoint, maxAttempts, backoff, consumer.DeadLetter))
+	}
+	return result
+}
+
+func findConsumerEndpoint(consumerEndpoint dto.ConsumerEndpoint, endpoints []vo.EndpointConfig) *vo.EndpointConfig {
+	for i := range endpoints {
+		if checker.Equals(endpoints[i].Path(), consumerEndpoint.Path) &&
+			checker.EqualsIgnoreCase(endpoints[i].Method(), consumerEndpoint.Method) {
+			return &endpoints[i]
+		}
+	}
+	return nil
 }
 
 func buildClient(server *dto.Server) *vo.ClientConfig {
@@ -89,12 +147,31 @@ func buildClient(server *dto.Server) *vo.ClientConfig {
 		}
 	}
 
-	return vo.NewClientConfig(timeout, maxIdleConns, maxIdleConnsPerHost, idleConnTimeout, cb, retry)
+	return vo.NewClientConfig(timeout, maxIdleConns, maxIdleConnsPerHost, idleConnTimeout, cb, retry,
+		buildClientTLS(server))
+}
+
+func buildClientTLS(server *dto.Server) []vo.ClientTLSConfig {
+	if checker.IsNil(server) || checker.IsNil(server.Client) {
+		return nil
+	}
+
+	var result []vo.ClientTLSConfig
+	for _, tls := range server.Client.TLS {
+		if checker.IsEmpty(tls.Hosts) {
+			panic(errors.Newf("server client tls requires 'hosts' property"))
+		}
+		result = append(result, vo.NewClientTLSConfig(tls.Hosts, tls.CertFile, tls.KeyFile, tls.CAFile,
+			tls.ServerName))
+	}
+	return result
 }
 
 func buildServer(server *dto.Server) *vo.ServerConfig {
 	var readTimeout, writeTimeout, readHeaderTimeout, idleTimeout vo.Duration
 	keepAlive := true
+	var grpc *vo.ServerGRPCConfig
+	var tls *vo.ServerTLSConfig
 
 	if checker.NonNil(server) {
 		if checker.NonNil(server.ReadTimeout) {
@@ -112,9 +189,35 @@ func buildServer(server *dto.Server) *vo.ServerConfig {
 		if checker.NonNil(server.KeepAlive) {
 			keepAlive = *server.KeepAlive
 		}
+		if checker.NonNil(server.GRPC) {
+			grpc = buildServerGRPC(server.GRPC)
+		}
+		if checker.NonNil(server.TLS) {
+			tls = buildServerTLS(server.TLS)
+		}
+	}
+
+	return vo.NewServerConfig(readTimeout, writeTimeout, readHeaderTimeout, idleTimeout, keepAlive, grpc, tls)
+}
+
+func buildServerTLS(tls *dto.ServerTLS) *vo.ServerTLSConfig {
+	if checker.IsEmpty(tls.CertFile) || checker.IsEmpty(tls.KeyFile) {
+		panic(errors.Newf("server tls requires 'cert-file' and 'key-file' properties"))
+	}
+	clientAuth := tls.ClientAuth
+	if checker.IsNotEmpty(clientAuth) && checker.NotEquals(clientAuth, enum.TLSClientAuthNone) &&
+		checker.IsEmpty(tls.ClientCAFile) {
+		panic(errors.Newf("server tls client-auth %s requires 'client-ca-file' property", clientAuth))
 	}
+	return vo.NewServerTLSConfig(tls.CertFile, tls.KeyFile, tls.ClientCAFile, clientAuth)
+}
 
-	return vo.NewServerConfig(readTimeout, writeTimeout, readHeaderTimeout, idleTimeout, keepAlive)
+func buildServerGRPC(grpc *dto.ServerGRPC) *vo.ServerGRPCConfig {
+	var port int
+	if checker.NonNil(grpc.Port) {
+		port = *grpc.Port
+	}
+	return vo.NewServerGRPCConfig(port, grpc.Descriptors)
 }
 
 func buildEndpoints(gopen *dto.Gopen) []vo.EndpointConfig {
@@ -149,18 +252,29 @@ func buildEndpoint(gopen *dto.Gopen, endpoint dto.Endpoint) vo.EndpointConfig {
 	}
 	return vo.NewEndpointConfig(
 		resolveEndpointExecution(gopen.Execution, endpoint.Execution),
+		endpoint.Protocol,
 		endpoint.Path,
 		endpoint.Method,
 		buildTimeout(gopen.Timeout, endpoint.Timeout),
 		buildSecurityCors(gopen.SecurityCors, endpoint.SecurityCors),
-		buildLimiter(gopen.Limiter, endpoint.Limiter),
+		buildAuth(gopen.Auth, endpoint.Auth),
+		buildLimiter(gopen.Limiter, endpoint.Limiter, gopen.Store),
 		buildEndpointCache(gopen.Cache, endpoint.Cache),
+		buildCacheInvalidate(endpoint.Invalidate),
 		buildBackends(gopen.Templates, gopen.Execution, endpoint, gopen),
 		buildEndpointResponse(endpoint.Response),
 		buildRequestClient(requestClient),
+		buildEndpointGRPC(endpoint.GRPC),
 	)
 }
 
+func buildEndpointGRPC(grpc *dto.EndpointGRPC) *vo.EndpointGRPCConfig {
+	if checker.IsNil(grpc) || checker.IsEmpty(grpc.Method) {
+		return nil
+	}
+	return vo.NewEndpointGRPCConfig(grpc.Method)
+}
+
 func buildTimeout(base, timeout vo.Duration) vo.Duration {
 	if checker.IsGreaterThan(timeout, 0) {
 		return timeout
@@ -215,25 +329,289 @@ func buildSecurityCors(securityCors *dto.SecurityCors, endpointSecurityCors *dto
 	return vo.NewSecurityCorsConfig(onlyIf, ignoreIf, allowOrigins, allowMethods, allowHeaders, allowCredentials)
 }
 
-func buildLimiter(limiter *dto.Limiter, endpointLimiter *dto.Limiter) *vo.LimiterConfig {
+func buildAuth(auth *dto.Auth, endpointAuth *dto.Auth) *vo.AuthConfig {
+	if checker.IsNil(auth) && checker.IsNil(endpointAuth) {
+		return nil
+	}
+
+	enabled := true
+	var jwt, endpointJWT *dto.AuthJWT
+	var introspection, endpointIntrospection *dto.AuthIntrospection
+	var apiKey, endpointAPIKey *dto.AuthAPIKey
+	var signature, endpointSignature *dto.AuthSignature
+	var scopes []string
+
+	if checker.NonNil(auth) {
+		if checker.NonNil(auth.Enabled) {
+			enabled = *auth.Enabled
+		}
+		jwt = auth.JWT
+		introspection = auth.Introspection
+		apiKey = auth.APIKey
+		signature = auth.Signature
+		scopes = auth.Scopes
+	}
+
+	if checker.NonNil(endpointAuth) {
+		if checker.NonNil(endpointAuth.Enabled) {
+			enabled = *endpointAuth.Enabled
+		}
+		endpointJWT = endpointAuth.JWT
+		endpointIntrospection = endpointAuth.Introspection
+		endpointAPIKey = endpointAuth.APIKey
+		endpointSignature = endpointAuth.Signature
+		if checker.NonNil(endpointAuth.Scopes) {
+			scopes = endpointAuth.Scopes
+		}
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	return vo.NewAuthConfig(buildAuthJWT(jwt, endpointJWT), buildAuthIntrospection(introspection,
+		endpointIntrospection), buildAuthAPIKey(apiKey, endpointAPIKey), buildAuthSignature(signature, endpointSignature),
+		scopes)
+}
+
+func buildAuthJWT(jwt, endpointJWT *dto.AuthJWT) *vo.AuthJWTConfig {
+	if checker.IsNil(jwt) && checker.IsNil(endpointJWT) {
+		return nil
+	}
+
+	var jwksURL, jwksFile, issuer string
+	var audience, algorithms []string
+	var jwksRefresh, clockSkew vo.Duration
+
+	if checker.NonNil(jwt) {
+		jwksURL = jwt.JWKSURL
+		jwksFile = jwt.JWKSFile
+		if checker.NonNil(jwt.JWKSRefresh) {
+			jwksRefresh = *jwt.JWKSRefresh
+		}
+		issuer = jwt.Issuer
+		audience = jwt.Audience
+		algorithms = jwt.Algorithms
+		if checker.NonNil(jwt.ClockSkew) {
+			clockSkew = *jwt.ClockSkew
+		}
+	}
+
+	if checker.NonNil(endpointJWT) {
+		if checker.IsNotEmpty(endpointJWT.JWKSURL) || checker.IsNotEmpty(endpointJWT.JWKSFile) {
+			jwksURL = endpointJWT.JWKSURL
+			jwksFile = endpointJWT.JWKSFile
+		}
+		if checker.NonNil(endpointJWT.JWKSRefresh) {
+			jwksRefresh = *endpointJWT.JWKSRefresh
+		}
+		if checker.IsNotEmpty(endpointJWT.Issuer) {
+			issuer = endpointJWT.Issuer
+		}
+		if checker.NonNil(endpointJWT.Audience) {
+			audience = endpointJWT.Audience
+		}
+		if checker.NonNil(endpointJWT.Algorithms) {
+			algorithms = endpointJWT.Algorithms
+		}
+		if checker.NonNil(endpointJWT.ClockSkew) {
+			clockSkew = *endpointJWT.ClockSkew
+		}
+	}
+
+	if checker.IsEmpty(jwksURL) && checker.IsEmpty(jwksFile) {
+		panic(errors.Newf("auth jwt requires 'jwks-url' or 'jwks-file' property (either at root level or endpoint level)"))
+	}
+
+	return vo.NewAuthJWTConfig(jwksURL, jwksFile, jwksRefresh, issuer, audience, algorithms, clockSkew)
+}
+
+func buildAuthIntrospection(introspection, endpointIntrospection *dto.AuthIntrospection,
+) *vo.AuthIntrospectionConfig {
+	if checker.IsNil(introspection) && checker.IsNil(endpointIntrospection) {
+		return nil
+	}
+
+	var url, clientID, clientSecret, tokenTypeHint string
+	var cacheTTL vo.Duration
+
+	if checker.NonNil(introspection) {
+		url = introspection.URL
+		clientID = introspection.ClientID
+		clientSecret = introspection.ClientSecret
+		tokenTypeHint = introspection.TokenTypeHint
+		if checker.NonNil(introspection.CacheTTL) {
+			cacheTTL = *introspection.CacheTTL
+		}
+	}
+
+	if checker.NonNil(endpointIntrospection) {
+		if checker.IsNotEmpty(endpointIntrospection.URL) {
+			url = endpointIntrospection.URL
+		}
+		if checker.IsNotEmpty(endpointIntrospection.ClientID) {
+			clientID = endpointIntrospection.ClientID
+			clientSecret = endpointIntrospection.ClientSecret
+		}
+		if checker.IsNotEmpty(endpointIntrospection.TokenTypeHint) {
+			tokenTypeHint = endpointIntrospection.TokenTypeHint
+		}
+		if checker.NonNil(endpointIntrospection.CacheTTL) {
+			cacheTTL = *endpointIntrospection.CacheTTL
+		}
+	}
+
+	if checker.IsEmpty(url) {
+		panic(errors.Newf("auth introspection requires 'url' property (either at root level or endpoint level)"))
+	}
+
+	return vo.NewAuthIntrospectionConfig(url, clientID, clientSecret, tokenTypeHint, cacheTTL)
+}
+
+func buildAuthAPIKey(apiKey, endpointAPIKey *dto.AuthAPIKey) *vo.AuthAPIKeyConfig {
+	if checker.IsNil(apiKey) && checker.IsNil(endpointAPIKey) {
+		return nil
+	}
+
+	var header, query, file string
+	var entries []dto.AuthAPIKeyEntry
+	var store bool
+
+	if checker.NonNil(apiKey) {
+		header = apiKey.Header
+		query = apiKey.Query
+		entries = apiKey.Keys
+		file = apiKey.File
+		store = apiKey.Store
+	}
+
+	if checker.NonNil(endpointAPIKey) {
+		if checker.IsNotEmpty(endpointAPIKey.Header) || checker.IsNotEmpty(endpointAPIKey.Query) {
+			header = endpointAPIKey.Header
+			query = endpointAPIKey.Query
+		}
+		if checker.NonNil(endpointAPIKey.Keys) {
+			entries = endpointAPIKey.Keys
+		}
+		if checker.IsNotEmpty(endpointAPIKey.File) {
+			file = endpointAPIKey.File
+		}
+		store = store || endpointAPIKey.Store
+	}
+
+	keys := map[string]*vo.APIConsumer{}
+	for _, entry := range entries {
+		keys[entry.Key] = vo.NewAPIConsumer(entry.Consumer, entry.Metadata)
+	}
+
+	return vo.NewAuthAPIKeyConfig(header, query, keys, file, store)
+}
+
+func buildAuthSignature(signature, endpointSignature *dto.AuthSignature) *vo.AuthSignatureConfig {
+	if checker.IsNil(signature) && checker.IsNil(endpointSignature) {
+		return nil
+	}
+
+	var header, prefix, secretEnv, canonical, timestampHeader string
+	var algorithm enum.HMACAlgorithm
+	var encoding enum.SignatureEncoding
+	var tolerance vo.Duration
+
+	if checker.NonNil(signature) {
+		header = signature.Header
+		prefix = signature.Prefix
+		algorithm = signature.Algorithm
+		encoding = signature.Encoding
+		secretEnv = signature.SecretEnv
+		canonical = signature.Canonical
+		if checker.NonNil(signature.Timestamp) {
+			timestampHeader = signature.Timestamp.Header
+			if checker.NonNil(signature.Timestamp.Tolerance) {
+				tolerance = *signature.Timestamp.Tolerance
+			}
+		}
+	}
+
+	if checker.NonNil(endpointSignature) {
+		if checker.IsNotEmpty(endpointSignature.Header) {
+			header = endpointSignature.Header
+			prefix = endpointSignature.Prefix
+		}
+		if checker.IsNotEmpty(endpointSignature.Algorithm) {
+			algorithm = endpointSignature.Algorithm
+		}
+		if checker.IsNotEmpty(endpointSignature.Encoding) {
+			encoding = endpointSignature.Encoding
+		}
+		if checker.IsNotEmpty(endpointSignature.SecretEnv) {
+			secretEnv = endpointSignature.SecretEnv
+		}
+		if checker.IsNotEmpty(endpointSignature.Canonical) {
+			canonical = endpointSignature.Canonical
+		}
+		if checker.NonNil(endpointSignature.Timestamp) {
+			timestampHeader = endpointSignature.Timestamp.Header
+			if checker.NonNil(endpointSignature.Timestamp.Tolerance) {
+				tolerance = *endpointSignature.Timestamp.Tolerance
+			}
+		}
+	}
+
+	if checker.IsEmpty(header) || checker.IsEmpty(secretEnv) {
+		panic(errors.Newf("auth signature requires 'header' and 'secret-env' properties (either at root level or " +
+			"endpoint level)"))
+	}
+
+	return vo.NewAuthSignatureConfig(header, prefix, algorithm, encoding, secretEnv, canonical, timestampHeader,
+		tolerance)
+}
+
+func buildLimiter(limiter *dto.Limiter, endpointLimiter *dto.Limiter, store *dto.Store) *vo.LimiterConfig {
 	if checker.IsNil(limiter) && checker.IsNil(endpointLimiter) {
 		return nil
 	}
 
 	var endpointSize, size *dto.LimiterSize
 	var endpointRate, rate *dto.LimiterRate
+	var rates []dto.LimiterRate
+	var endpointConcurrency, concurrency, globalConcurrency *dto.LimiterConcurrency
 
 	if checker.NonNil(limiter) {
 		size = limiter.Size
 		rate = limiter.Rate
+		rates = limiter.Rates
+		concurrency = limiter.Concurrency
+		globalConcurrency = limiter.GlobalConcurrency
 	}
 
 	if checker.NonNil(endpointLimiter) {
 		endpointSize = endpointLimiter.Size
 		endpointRate = endpointLimiter.Rate
+		if checker.NonNil(endpointLimiter.Rates) {
+			rates = endpointLimiter.Rates
+		}
+		endpointConcurrency = endpointLimiter.Concurrency
+		if checker.NonNil(endpointLimiter.GlobalConcurrency) {
+			panic(errors.Newf("limiter 'global-concurrency' property is only allowed at root level"))
+		}
+	}
+
+	var rateConfigs []*vo.LimiterRateConfig
+	if rateConfig := buildLimiterRate(rate, endpointRate); checker.NonNil(rateConfig) {
+		rateConfigs = append(rateConfigs, rateConfig)
+	}
+	for _, rateRule := range rates {
+		rateConfigs = append(rateConfigs, buildLimiterRate(&rateRule, nil))
 	}
 
-	return vo.NewLimiterConfig(buildLimiterSize(size, endpointSize), buildLimiterRate(rate, endpointRate))
+	for _, rateConfig := range rateConfigs {
+		if rateConfig.HasDistributed() && checker.IsNil(store) {
+			panic(errors.Newf("limiter rate distributed requires 'store' property"))
+		}
+	}
+
+	return vo.NewLimiterConfig(buildLimiterSize(size, endpointSize), rateConfigs,
+		buildLimiterConcurrency(concurrency, endpointConcurrency), buildLimiterConcurrency(globalConcurrency, nil))
 }
 
 func buildLimiterSize(size, endpointSize *dto.LimiterSize) *vo.LimiterSizeConfig {
@@ -269,28 +647,155 @@ func buildLimiterRate(rate, endpointRate *dto.LimiterRate) *vo.LimiterRateConfig
 		return nil
 	}
 
+	var onlyIf, ignoreIf []string
+	var key string
 	var every vo.Duration
 	var capacity int
+	var distributed, endpointDistributed *dto.LimiterRateDistributed
 
 	if checker.NonNil(rate) {
+		onlyIf = rate.OnlyIf
+		ignoreIf = rate.IgnoreIf
+		key = rate.Key
 		if checker.NonNil(rate.Every) {
 			every = *rate.Every
 		}
 		if checker.NonNil(rate.Capacity) {
 			capacity = *rate.Capacity
 		}
+		distributed = rate.Distributed
 	}
 
 	if checker.NonNil(endpointRate) {
+		if checker.NonNil(endpointRate.OnlyIf) {
+			onlyIf = endpointRate.OnlyIf
+		}
+		if checker.NonNil(endpointRate.IgnoreIf) {
+			ignoreIf = endpointRate.IgnoreIf
+		}
+		if checker.IsNotEmpty(endpointRate.Key) {
+			key = endpointRate.Key
+		}
 		if checker.NonNil(endpointRate.Every) {
 			every = *endpointRate.Every
 		}
 		if checker.NonNil(endpointRate.Capacity) {
 			capacity = *endpointRate.Capacity
 		}
+		endpointDistributed = endpointRate.Distributed
+	}
+
+	return vo.NewLimiterRateConfig(onlyIf, ignoreIf, key, every, capacity, buildLimiterRateDistributed(distributed,
+		endpointDistributed))
+}
+
+func buildLimiterConcurrency(concurrency, endpointConcurrency *dto.LimiterConcurrency,
+) *vo.LimiterConcurrencyConfig {
+	if checker.IsNil(concurrency) && checker.IsNil(endpointConcurrency) {
+		return nil
+	}
+
+	var maxInFlight, maxQueue int
+	var queueTimeout vo.Duration
+	var adaptive, endpointAdaptive *dto.LimiterConcurrencyAdaptive
+
+	if checker.NonNil(concurrency) {
+		if checker.NonNil(concurrency.MaxInFlight) {
+			maxInFlight = *concurrency.MaxInFlight
+		}
+		if checker.NonNil(concurrency.MaxQueue) {
+			maxQueue = *concurrency.MaxQueue
+		}
+		if checker.NonNil(concurrency.QueueTimeout) {
+			queueTimeout = *concurrency.QueueTimeout
+		}
+		adaptive = concurrency.Adaptive
+	}
+
+	if checker.NonNil(endpointConcurrency) {
+		if checker.NonNil(endpointConcurrency.MaxInFlight) {
+			maxInFlight = *endpointConcurrency.MaxInFlight
+		}
+		if checker.NonNil(endpointConcurrency.MaxQueue) {
+			maxQueue = *endpointConcurrency.MaxQueue
+		}
+		if checker.NonNil(endpointConcurrency.QueueTimeout) {
+			queueTimeout = *endpointConcurrency.QueueTimeout
+		}
+		endpointAdaptive = endpointConcurrency.Adaptive
+	}
+
+	return vo.NewLimiterConcurrencyConfig(maxInFlight, maxQueue, queueTimeout,
+		buildLimiterConcurrencyAdaptive(adaptive, endpointAdaptive))
+}
+
+func buildLimiterConcurrencyAdaptive(adaptive, endpointAdaptive *dto.LimiterConcurrencyAdaptive,
+) *vo.LimiterConcurrencyAdaptiveConfig {
+	if checker.IsNil(adaptive) && checker.IsNil(endpointAdaptive) {
+		return nil
+	}
+
+	var maxLatency, window vo.Duration
+	var maxGoroutines int
+
+	if checker.NonNil(adaptive) {
+		if checker.NonNil(adaptive.MaxLatency) {
+			maxLatency = *adaptive.MaxLatency
+		}
+		if checker.NonNil(adaptive.MaxGoroutines) {
+			maxGoroutines = *adaptive.MaxGoroutines
+		}
+		if checker.NonNil(adaptive.Window) {
+			window = *adaptive.Window
+		}
+	}
+
+	if checker.NonNil(endpointAdaptive) {
+		if checker.NonNil(endpointAdaptive.MaxLatency) {
+			maxLatency = *endpointAdaptive.MaxLatency
+		}
+		if checker.NonNil(endpointAdaptive.MaxGoroutines) {
+			maxGoroutines = *endpointAdaptive.MaxGoroutines
+		}
+		if checker.NonNil(endpointAdaptive.Window) {
+			window = *endpointAdaptive.Window
+		}
+	}
+
+	return vo.NewLimiterConcurrencyAdaptiveConfig(maxLatency, maxGoroutines, window)
+}
+
+func buildLimiterRateDistributed(distributed, endpointDistributed *dto.LimiterRateDistributed,
+) *vo.LimiterRateDistributedConfig {
+	if checker.IsNil(distributed) && checker.IsNil(endpointDistributed) {
+		return nil
+	}
+
+	var algorithm enum.LimiterAlgorithm
+	var onFailure enum.LimiterOnFailure
+	var timeout vo.Duration
+
+	if checker.NonNil(distributed) {
+		algorithm = distributed.Algorithm
+		onFailure = distributed.OnFailure
+		if checker.NonNil(distributed.Timeout) {
+			timeout = *distributed.Timeout
+		}
 	}
 
-	return vo.NewLimiterRateConfig(every, capacity)
+	if checker.NonNil(endpointDistributed) {
+		if checker.IsNotEmpty(endpointDistributed.Algorithm) {
+			algorithm = endpointDistributed.Algorithm
+		}
+		if checker.IsNotEmpty(endpointDistributed.OnFailure) {
+			onFailure = endpointDistributed.OnFailure
+		}
+		if checker.NonNil(endpointDistributed.Timeout) {
+			timeout = *endpointDistributed.Timeout
+		}
+	}
+
+	return vo.NewLimiterRateDistributedConfig(algorithm, onFailure, timeout)
 }
 
 func buildEndpointCache(cache *dto.Cache, endpointCache *dto.Cache) *vo.CacheConfig {
@@ -319,7 +824,12 @@ func buildEndpointCache(cache *dto.Cache, endpointCache *dto.Cache) *vo.CacheCon
 			mergeCacheConditions(effective.IgnoreIf, effective.Write.IgnoreIf),
 		),
 		effective.Key,
+		effective.Tags,
 		effective.TTL,
+		effective.StaleWhileRevalidate,
+		effective.StaleIfError,
+		buildCacheCoalesce(effective.Coalesce),
+		buildCacheHTTP(effective.HTTP),
 	)
 }
 
@@ -352,12 +862,69 @@ func mergeCacheDTO(base, override *dto.Cache) dto.Cache {
 	if checker.IsNotEmpty(override.Key) {
 		result.Key = override.Key
 	}
+	if checker.IsNotEmpty(override.Tags) {
+		result.Tags = override.Tags
+	}
 	if override.TTL > 0 {
 		result.TTL = override.TTL
 	}
+	if override.StaleWhileRevalidate > 0 {
+		result.StaleWhileRevalidate = override.StaleWhileRevalidate
+	}
+	if override.StaleIfError > 0 {
+		result.StaleIfError = override.StaleIfError
+	}
+	if checker.NonNil(override.Coalesce) {
+		result.Coalesce = override.Coalesce
+	}
+	if checker.NonNil(override.HTTP) {
+		result.HTTP = override.HTTP
+	}
 	return result
 }
 
+func buildCacheInvalidate(invalidate *dto.CacheInvalidate) *vo.CacheInvalidateConfig {
+	if checker.IsNil(invalidate) {
+		return nil
+	} else if checker.IsEmpty(invalidate.Keys) && checker.IsEmpty(invalidate.Patterns) &&
+		checker.IsEmpty(invalidate.Tags) {
+		panic(errors.Newf("invalidate requires 'keys', 'patterns' or 'tags' property"))
+	}
+
+	for _, key := range append(append([]string{}, invalidate.Keys...), invalidate.Patterns...) {
+		if !strings.HasPrefix(key, fmt.Sprintf("%s:", enum.CacheKindEndpoint)) &&
+			!strings.HasPrefix(key, fmt.Sprintf("%s:", enum.CacheKindBackend)) {
+			panic(errors.Newf("invalidate key %s must start with %s: or %s:", key, enum.CacheKindEndpoint,
+				enum.CacheKindBackend))
+		}
+	}
+
+	return vo.NewCacheInvalidateConfig(invalidate.Keys, invalidate.Patterns, invalidate.Tags)
+}
+
+func buildCacheCoalesce(coalesce *dto.CacheCoalesce) *vo.CacheCoalesceConfig {
+	if checker.IsNil(coalesce) {
+		return nil
+	}
+	return vo.NewCacheCoalesceConfig(coalesce.LockTTL, coalesce.Wait)
+}
+
+func buildCacheHTTP(cacheHTTP *dto.CacheHTTP) *vo.CacheHTTPConfig {
+	if checker.IsNil(cacheHTTP) {
+		return nil
+	} else if checker.IsNotEmpty(cacheHTTP.TTL) && !cacheHTTP.TTL.IsEnumValid() {
+		panic(errors.Newf("invalid cache http ttl %q, must be SET or CAP", cacheHTTP.TTL))
+	}
+
+	var noCache *vo.CacheDecisionConfig
+	if checker.NonNil(cacheHTTP.NoCache) {
+		decision := vo.NewCacheDecisionConfig(cacheHTTP.NoCache.OnlyIf, cacheHTTP.NoCache.IgnoreIf)
+		noCache = &decision
+	}
+
+	return vo.NewCacheHTTPConfig(cacheHTTP.TTL, noCache)
+}
+
 func mergeCacheConditions(base, specific []string) []string {
 	return append(base, specific...)
 }
@@ -481,6 +1048,8 @@ func buildBackendUnified(
 ) vo.BackendConfig {
 	var http *vo.BackendHTTPConfig
 	var publisher *vo.BackendPublisherConfig
+	var grpc *vo.BackendGRPCConfig
+	var graphQL *vo.BackendGraphQLConfig
 
 	switch backend.Kind {
 	case enum.BackendKindPublisher:
@@ -489,6 +1058,7 @@ func buildBackendUnified(
 			backend.Path,
 			backend.GroupID,
 			backend.DeduplicationID,
+			backend.RoutingKey,
 			backend.Delay,
 			buildPublisherMessage(backend.Message),
 		)
@@ -498,6 +1068,24 @@ func buildBackendUnified(
 			backend.Path,
 			backend.Method,
 			buildHTTPBackendRequest(backend, ps, backendIndex, gopen),
+			buildBackendSigning(backend.Signing),
+			buildBackendCredential(backend.Credential, gopen.Credentials),
+		)
+	case enum.BackendKindGRPC:
+		grpc = vo.NewBackendGRPCConfig(
+			backend.Hosts,
+			backend.Path,
+			backend.Descriptors,
+			buildGRPCBackendRequest(backend, ps, backendIndex, gopen),
+		)
+	case enum.BackendKindGraphQL:
+		graphQL = vo.NewBackendGraphQLConfig(
+			backend.Hosts,
+			backend.Path,
+			backend.Query,
+			backend.OperationName,
+			backend.Variables,
+			buildGraphQLBackendHeader(backend, ps, backendIndex, gopen),
 		)
 	default:
 		panic(errors.Newf("invalid backend.kind=%v (endpoint=%s %s)", backend.Kind, flow, backend.Path))
@@ -512,12 +1100,67 @@ func buildBackendUnified(
 		buildBackendDependencies(backend.Dependencies, idToIndex),
 		backend.Kind,
 		buildBackendCache(backend.Cache),
+		buildCacheInvalidate(backend.Invalidate),
 		http,
 		publisher,
+		grpc,
+		graphQL,
 		buildBackendResponse(backend, flow),
 	)
 }
 
+func buildBackendSigning(signing *dto.BackendSigning) *vo.BackendSigningConfig {
+	if checker.IsNil(signing) {
+		return nil
+	}
+
+	var aws *vo.BackendSigningAWSConfig
+	if checker.NonNil(signing.AWS) {
+		if checker.IsEmpty(signing.AWS.Service) {
+			panic(errors.Newf("backend signing aws requires 'service' property"))
+		}
+		aws = vo.NewBackendSigningAWSConfig(signing.AWS.Service, signing.AWS.Region)
+	}
+
+	var hmac *vo.BackendSigningHMACConfig
+	if checker.NonNil(signing.HMAC) {
+		if checker.IsEmpty(signing.HMAC.Header) || checker.IsEmpty(signing.HMAC.SecretEnv) {
+			panic(errors.Newf("backend signing hmac requires 'header' and 'secret-env' properties"))
+		}
+		hmac = vo.NewBackendSigningHMACConfig(signing.HMAC.Header, signing.HMAC.Prefix, signing.HMAC.Algorithm,
+			signing.HMAC.Encoding, signing.HMAC.SecretEnv, signing.HMAC.Canonical, signing.HMAC.TimestampHeader)
+	}
+
+	return vo.NewBackendSigningConfig(aws, hmac)
+}
+
+// buildBackendCredential resolves the credential referenced by name, which must be declared in the credentials section.
+func buildBackendCredential(name string, credentials map[string]dto.Credential) *vo.CredentialConfig {
+	if checker.IsEmpty(name) {
+		return nil
+	}
+
+	credential, ok := credentials[name]
+	if !ok {
+		panic(errors.Newf("backend references a credential not declared: credential=%s", name))
+	} else if checker.IsNil(credential.OAuth2) {
+		panic(errors.Newf("credential %s requires 'oauth2' property", name))
+	}
+
+	oauth2 := credential.OAuth2
+	if checker.IsEmpty(oauth2.TokenURL) || checker.IsEmpty(oauth2.ClientID) {
+		panic(errors.Newf("credential %s oauth2 requires 'token-url' and 'client-id' properties", name))
+	}
+
+	var refreshBefore vo.Duration
+	if checker.NonNil(oauth2.RefreshBefore) {
+		refreshBefore = *oauth2.RefreshBefore
+	}
+
+	return vo.NewCredentialConfig(name, oauth2.TokenURL, oauth2.ClientID, oauth2.ClientSecret, oauth2.Scopes,
+		oauth2.Audience, refreshBefore)
+}
+
 func buildBackendDependencies(deps []string, idToIndex map[string]int) *vo.BackendDependenciesConfig {
 	if checker.IsEmpty(deps) {
 		return nil
@@ -557,7 +1200,12 @@ func buildBackendCache(cache *dto.Cache) *vo.CacheConfig {
 			mergeCacheConditions(cache.IgnoreIf, cache.Write.IgnoreIf),
 		),
 		cache.Key,
+		cache.Tags,
 		cache.TTL,
+		cache.StaleWhileRevalidate,
+		cache.StaleIfError,
+		buildCacheCoalesce(cache.Coalesce),
+		buildCacheHTTP(cache.HTTP),
 	)
 }
 
@@ -578,6 +1226,39 @@ func buildHTTPBackendRequest(backend dto.Backend, ps *propagateState, backendInd
 	)
 }
 
+// buildGRPCBackendRequest follows buildHTTPBackendRequest, only the header and body are meaningful to a gRPC call,
+// they are sent as the call metadata and the input message.
+func buildGRPCBackendRequest(backend dto.Backend, ps *propagateState, backendIndex int, gopen *dto.Gopen,
+) vo.BackendGRPCRequestConfig {
+	effective := resolveBackendRequestComponents(backend.Request, gopen)
+
+	effective = mergeBackendRequestWithPropagation(effective, ps)
+
+	effectivePropagate := resolveBackendPropagateComponents(backend.Propagate, gopen)
+	applyBackendPropagateIntoState(effectivePropagate, ps, backendIndex)
+	collectPropagatingModifiersFromRequestIntoState(backend.Request, ps, backendIndex)
+
+	return vo.NewBackendGRPCRequestConfig(
+		buildMetadata(effective.Header),
+		buildPayload(effective.Body),
+	)
+}
+
+// buildGraphQLBackendHeader follows buildHTTPBackendRequest, the body of a GraphQL call is the query document itself,
+// so only the header transformations are kept.
+func buildGraphQLBackendHeader(backend dto.Backend, ps *propagateState, backendIndex int, gopen *dto.Gopen,
+) *vo.MetadataConfig {
+	effective := resolveBackendRequestComponents(backend.Request, gopen)
+
+	effective = mergeBackendRequestWithPropagation(effective, ps)
+
+	effectivePropagate := resolveBackendPropagateComponents(backend.Propagate, gopen)
+	applyBackendPropagateIntoState(effectivePropagate, ps, backendIndex)
+	collectPropagatingModifiersFromRequestIntoState(backend.Request, ps, backendIndex)
+
+	return buildMetadata(effective.Header)
+}
+
 func buildBackendResponse(backend dto.Backend, flow enum.BackendFlow) *vo.BackendResponseConfig {
 	if checker.Equals(flow, enum.BackendFlowBeforeware) || checker.Equals(flow, enum.BackendFlowAfterware) {
 		return buildMiddlewareBackendResponse(backend)
@@ -921,6 +1602,19 @@ func keepTemplateBase(tpl dto.Backend) dto.Backend {
 		out.Hosts = tpl.Hosts
 		out.Path = tpl.Path
 		out.Method = tpl.Method
+		out.Signing = tpl.Signing
+		out.Credential = tpl.Credential
+		return out
+	case enum.BackendKindGRPC:
+		out.Hosts = tpl.Hosts
+		out.Path = tpl.Path
+		out.Descriptors = tpl.Descriptors
+		return out
+	case enum.BackendKindGraphQL:
+		out.Hosts = tpl.Hosts
+		out.Path = tpl.Path
+		out.Query = tpl.Query
+		out.OperationName = tpl.OperationName
 		return out
 	default:
 		return out
@@ -940,6 +1634,21 @@ func forceLockedFieldsFromTemplate(tpl dto.Backend, merged dto.Backend) dto.Back
 		merged.Hosts = tpl.Hosts
 		merged.Path = tpl.Path
 		merged.Method = tpl.Method
+		merged.Signing = tpl.Signing
+		merged.Credential = tpl.Credential
+		return merged
+
+	case enum.BackendKindGRPC:
+		merged.Hosts = tpl.Hosts
+		merged.Path = tpl.Path
+		merged.Descriptors = tpl.Descriptors
+		return merged
+
+	case enum.BackendKindGraphQL:
+		merged.Hosts = tpl.Hosts
+		merged.Path = tpl.Path
+		merged.Query = tpl.Query
+		merged.OperationName = tpl.OperationName
 		return merged
 
 	default:
@@ -977,6 +1686,34 @@ func mergeBackendFULL(tpl dto.Backend, cur dto.Backend) dto.Backend {
 	if checker.IsNotEmpty(cur.Method) {
 		out.Method = cur.Method
 	}
+	if checker.NonNil(cur.Signing) {
+		out.Signing = cur.Signing
+	}
+	if checker.IsNotEmpty(cur.Credential) {
+		out.Credential = cur.Credential
+	}
+	if checker.NonNil(cur.Invalidate) {
+		out.Invalidate = cur.Invalidate
+	}
+	if checker.IsNotEmpty(cur.Descriptors) {
+		out.Descriptors = cur.Descriptors
+	}
+	if checker.IsNotEmpty(cur.Query) {
+		out.Query = cur.Query
+	}
+	if checker.IsNotEmpty(cur.OperationName) {
+		out.OperationName = cur.OperationName
+	}
+	if checker.NonNil(cur.Variables) {
+		variables := map[string]any{}
+		for k, v := range out.Variables {
+			variables[k] = v
+		}
+		for k, v := range cur.Variables {
+			variables[k] = v
+		}
+		out.Variables = variables
+	}
 
 	out.Request = mergeBackendRequest(out.Request, cur.Request)
 	out.Response = mergeBackendResponse(out.Response, cur.Response)
@@ -991,6 +1728,9 @@ func mergeBackendFULL(tpl dto.Backend, cur dto.Backend) dto.Backend {
 	if checker.IsNotEmpty(cur.DeduplicationID) {
 		out.DeduplicationID = cur.DeduplicationID
 	}
+	if checker.IsNotEmpty(cur.RoutingKey) {
+		out.RoutingKey = cur.RoutingKey
+	}
 	if checker.IsGreaterThan(cur.Delay, 0) {
 		out.Delay = cur.Delay
 	}
@@ -1483,7 +2223,7 @@ func buildRequestClientTransportHeaders(d *dto.RequestClientTransportHeaders) *v
 	}
 	var resp vo.RequestClientTransportHeadersResponseConfig
 	if checker.NonNil(d.Response) {
-		resp = vo.NewRequestClientTransportHeadersResponseConfig(d.Response.Cache, d.Response.BackendCache, d.Response.ExecutionStatus, d.Response.Degradation)
+		resp = vo.NewRequestClientTransportHeadersResponseConfig(d.Response.Cache, d.Response.BackendCache, d.Response.ExecutionStatus, d.Response.Degradation, d.Response.RateLimit)
 	}
 	return vo.NewRequestClientTransportHeadersConfig(req, resp)
 }
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interceptor
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+type authMiddleware struct {
+	jwtValidator      app.JWTValidator
+	tokenIntrospector app.TokenIntrospector
+	apiKeyResolver    app.APIKeyResolver
+	signatureVerifier app.SignatureVerifier
+}
+
+type Auth interface {
+	Do(ctx app.Context)
+}
+
+func NewAuth(jwtValidator app.JWTValidator, tokenIntrospector app.TokenIntrospector,
+	apiKeyResolver app.APIKeyResolver, signatureVerifier app.SignatureVerifier) Auth {
+	return authMiddleware{
+		jwtValidator:      jwtValidator,
+		tokenIntrospector: tokenIntrospector,
+		apiKeyResolver:    apiKeyResolver,
+		signatureVerifier: signatureVerifier,
+	}
+}
+
+// Do requires every configured method, the signature authenticates the raw request, the API key identifies the
+// consumer and the bearer token the user.
+func (a authMiddleware) Do(ctx app.Context) {
+	if !ctx.Endpoint().HasAuth() || ctx.Request().IsPreflight() {
+		ctx.Next()
+		return
+	}
+
+	auth := ctx.Endpoint().Auth()
+
+	if auth.HasSignature() {
+		err := a.signatureVerifier.Verify(auth.Signature(), ctx.Request())
+		if checker.NonNil(err) {
+			a.writeError(ctx, err)
+			return
+		}
+	}
+
+	if auth.HasAPIKey() {
+		consumer, err := a.resolveConsumer(ctx, auth.APIKey())
+		if checker.NonNil(err) {
+			a.writeError(ctx, err)
+			return
+		}
+		ctx.WithRequest(ctx.Request().WithConsumer(consumer))
+	}
+
+	if auth.HasBearer() {
+		claims, err := a.authenticate(ctx, auth)
+		if checker.NonNil(err) {
+			a.writeError(ctx, err)
+			return
+		}
+		ctx.WithRequest(ctx.Request().WithAuthClaims(claims))
+	}
+
+	ctx.Next()
+}
+
+func (a authMiddleware) writeError(ctx app.Context, err error) {
+	if errors.Is(err, app.ErrAuthUnauthenticated) {
+		ctx.WriteError(enum.ResponseStatusUnauthenticated, err)
+	} else if errors.Is(err, app.ErrAuthPermissionDenied) {
+		ctx.WriteError(enum.ResponseStatusPermissionDenied, err)
+	} else {
+		ctx.WriteError(enum.ResponseStatusInternalError, err)
+	}
+}
+
+func (a authMiddleware) resolveConsumer(ctx app.Context, config *vo.AuthAPIKeyConfig) (*vo.APIConsumer, error) {
+	var key string
+	if checker.IsNotEmpty(config.Header()) {
+		key = ctx.Request().Metadata().GetFirst(config.Header())
+	}
+	if checker.IsEmpty(key) && checker.IsNotEmpty(config.Query()) {
+		key = ctx.Request().Query().GetFirst(config.Query())
+	}
+	if checker.IsEmpty(key) {
+		return nil, app.NewErrAuthUnauthenticated("api key not informed")
+	}
+	return a.apiKeyResolver.Resolve(ctx.Context(), config, key)
+}
+
+func (a authMiddleware) authenticate(ctx app.Context, auth *vo.AuthConfig) (map[string]any, error) {
+	token, ok := a.extractBearerToken(ctx)
+	if !ok {
+		return nil, app.NewErrAuthUnauthenticated("bearer token not informed")
+	}
+
+	claims, err := a.validateToken(ctx, auth, token)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	if missingScopes := a.missingScopes(auth, claims); checker.IsNotEmpty(missingScopes) {
+		return nil, app.NewErrAuthPermissionDenied(missingScopes)
+	}
+	return claims, nil
+}
+
+// validateToken validates the token as a JWT when configured, falling back to the introspection endpoint for tokens
+// the JWT path can't check, such as opaque ones.
+func (a authMiddleware) validateToken(ctx app.Context, auth *vo.AuthConfig, token string) (map[string]any, error) {
+	var claims map[string]any
+	var err error
+
+	if auth.HasJWT() {
+		claims, err = a.jwtValidator.Validate(ctx.Context(), auth.JWT(), token)
+		if !auth.HasIntrospection() || !errors.Is(err, app.ErrAuthUnauthenticated) {
+			return claims, err
+		}
+	}
+
+	return a.tokenIntrospector.Introspect(ctx.Context(), auth.Introspection(), ctx.Request(), token)
+}
+
+func (a authMiddleware) missingScopes(auth *vo.AuthConfig, claims map[string]any) []string {
+	if !auth.HasScopes() {
+		return nil
+	}
+
+	var granted []string
+	switch scope := claims["scope"].(type) {
+	case string:
+		granted = strings.Fields(scope)
+	case []any:
+		for _, s := range scope {
+			granted = append(granted, converter.ToString(s))
+		}
+	}
+	if scp, ok := claims["scp"].([]any); ok {
+		for _, s := range scp {
+			granted = append(granted, converter.ToString(s))
+		}
+	}
+
+	var missing []string
+	for _, required := range auth.Scopes() {
+		if !slices.Contains(granted, required) {
+			missing = append(missing, required)
+		}
+	}
+	return missing
+}
+
+func (a authMiddleware) extractBearerToken(ctx app.Context) (string, bool) {
+	scheme, token, found := strings.Cut(ctx.Request().Metadata().GetFirst("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || checker.IsEmpty(strings.TrimSpace(token)) {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
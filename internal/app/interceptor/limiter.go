@@ -17,6 +17,8 @@
 package interceptor
 
 import (
+	"fmt"
+
 	"github.com/tech4works/checker"
 	"github.com/tech4works/errors"
 	"github.com/tech4works/gopen-gateway/internal/app"
@@ -45,7 +47,11 @@ func (l limiterMiddleware) Do(ctx app.Context) {
 		return
 	}
 
-	err := l.service.AllowRate(ctx.Endpoint().Limiter().Rate(), ctx.Request())
+	statuses, err := l.service.AllowRate(ctx.Context(), ctx.Endpoint().Limiter().Rates(), ctx.Request())
+	if ctx.Endpoint().RequestClient().TransportHeadersResponse().RateLimitEnabled() {
+		ctx.WriteMetadata(l.service.BuildRateMetadata(statuses))
+	}
+
 	if errors.Is(err, domain.ErrLimiterTooManyRequests) {
 		ctx.WriteError(enum.ResponseStatusResourceExhausted, err)
 		return
@@ -57,11 +63,30 @@ func (l limiterMiddleware) Do(ctx app.Context) {
 	err = l.service.AllowSize(ctx.Endpoint().Limiter().Size(), ctx.Request())
 	if errors.Is(err, domain.ErrLimiterMetadataTooLarge) {
 		ctx.WriteError(enum.ResponseStatusMetadataTooLarge, err)
+		return
 	} else if errors.Is(err, domain.ErrLimiterPayloadTooLarge) {
 		ctx.WriteError(enum.ResponseStatusPayloadTooLarge, err)
+		return
 	} else if checker.NonNil(err) {
 		ctx.WriteError(enum.ResponseStatusInternalError, err)
-	} else {
+		return
+	}
+
+	if !ctx.Endpoint().Limiter().HasConcurrency() && !ctx.Endpoint().Limiter().HasGlobalConcurrency() {
 		ctx.Next()
+		return
+	}
+
+	endpoint := fmt.Sprintf("%s %s", ctx.Endpoint().Method(), ctx.Endpoint().Path())
+	release, err := l.service.AcquireConcurrency(ctx.Context(), ctx.Endpoint().Limiter(), endpoint)
+	if errors.Is(err, domain.ErrLimiterConcurrencyExceeded) || errors.Is(err, domain.ErrLimiterLoadShed) {
+		ctx.WriteError(enum.ResponseStatusUnavailable, err)
+		return
+	} else if checker.NonNil(err) {
+		ctx.WriteError(enum.ResponseStatusInternalError, err)
+		return
 	}
+	defer release()
+
+	ctx.Next()
 }
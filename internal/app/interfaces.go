@@ -21,8 +21,11 @@ import (
 	"net/http"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
 	"github.com/tech4works/gopen-gateway/internal/app/model/publisher"
+	"github.com/tech4works/gopen-gateway/internal/app/model/rpc"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
 )
@@ -51,6 +54,11 @@ type Router interface {
 	Handle(gopen *vo.GopenConfig, endpoint *vo.EndpointConfig, handles ...HandlerFunc)
 }
 
+type GRPCRouter interface {
+	Engine() *grpc.Server
+	Handle(gopen *vo.GopenConfig, endpoint *vo.EndpointConfig, handles ...HandlerFunc)
+}
+
 type Context interface {
 	Context() context.Context
 	WithContext(ctx context.Context)
@@ -62,6 +70,7 @@ type Context interface {
 	Gopen() *vo.GopenConfig
 	Endpoint() *vo.EndpointConfig
 	Request() *vo.EndpointRequest
+	WithRequest(request *vo.EndpointRequest)
 	Response() *vo.EndpointResponse
 	Write(response *vo.EndpointResponse)
 	WriteError(status enum.ResponseStatus, err error)
@@ -69,17 +78,71 @@ type Context interface {
 	WriteJSON(status enum.ResponseStatus, a any)
 	WriteStatus(status enum.ResponseStatus)
 	WriteMetadata(metadata vo.Metadata)
+	UpgradeWebSocket(subprotocol string) (WebSocketConn, error)
+}
+
+type JWTValidator interface {
+	Validate(ctx context.Context, config *vo.AuthJWTConfig, token string) (map[string]any, error)
+	Close()
+}
+
+// TokenIntrospector resolves an opaque token through the introspection endpoint (RFC 7662), returning the claims of an
+// active token.
+type TokenIntrospector interface {
+	Introspect(ctx context.Context, config *vo.AuthIntrospectionConfig, parent *vo.EndpointRequest, token string) (
+		map[string]any, error)
+}
+
+type APIKeyResolver interface {
+	Resolve(ctx context.Context, config *vo.AuthAPIKeyConfig, key string) (*vo.APIConsumer, error)
+}
+
+type SignatureVerifier interface {
+	Verify(config *vo.AuthSignatureConfig, request *vo.EndpointRequest) error
+}
+
+// CredentialProvider supplies the bearer token of the backends that reference a credential, Invalidate discards the
+// cached token once the backend rejected it.
+type CredentialProvider interface {
+	Token(ctx context.Context, config *vo.CredentialConfig, parent *vo.EndpointRequest) (string, error)
+	Invalidate(ctx context.Context, config *vo.CredentialConfig, token string)
 }
 
 type HTTPClient interface {
 	MakeRequest(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest, request *vo.HTTPBackendRequest) (*http.Response, error)
 }
 
+type WebSocketClient interface {
+	Dial(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest, request *vo.HTTPBackendRequest) (
+		WebSocketConn, error)
+}
+
+type WebSocketConn interface {
+	ReadMessage() (*vo.WebSocketMessage, error)
+	WriteMessage(message *vo.WebSocketMessage) error
+	Subprotocol() string
+	Close() error
+}
+
 type PublisherClient interface {
 	Publish(ctx context.Context, parent *vo.EndpointRequest, request *vo.PublisherBackendRequest) (*publisher.Response,
 		error)
 }
 
+type GRPCClient interface {
+	Load(backend *vo.BackendGRPCConfig) error
+	Invoke(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest, request *vo.GRPCBackendRequest) (
+		*rpc.Response, error)
+	Close()
+}
+
+// ConsumerHandler executes the request built from a received message, returning whether it must be acknowledged.
+type ConsumerHandler func(ctx context.Context, request *vo.EndpointRequest) bool
+
+type ConsumerClient interface {
+	Consume(ctx context.Context, consumer *vo.ConsumerConfig, handler ConsumerHandler) error
+}
+
 type HTTPLog interface {
 	PrintRequest(ctx Context)
 	PrintResponse(ctx Context)
@@ -106,6 +169,7 @@ type EndpointLog interface {
 type BackendLog interface {
 	PrintHTTPRequest(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, request *vo.HTTPBackendRequest)
 	PrintPublisherRequest(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, request *vo.PublisherBackendRequest)
+	PrintGRPCRequest(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, request *vo.GRPCBackendRequest)
 	PrintResponse(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, response *vo.BackendResponse)
 	PrintInfof(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, format string, msg ...any)
 	PrintInfo(executeData dto.ExecuteEndpoint, backend *vo.BackendConfig, msg ...any)
@@ -17,6 +17,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
@@ -24,20 +25,32 @@ import (
 )
 
 type Gopen struct {
-	Comment      string          `json:"@comment,omitempty"`
-	Version      string          `json:"version,omitempty"`
-	HotReload    bool            `json:"hot-reload,omitempty"`
-	Server       *Server         `json:"server,omitempty"`
-	Store        *Store          `json:"store,omitempty"`
-	Timeout      vo.Duration     `json:"timeout,omitempty"`
-	Execution    *GopenExecution `json:"execution,omitempty"`
-	SecurityCors *SecurityCors   `json:"security-cors,omitempty"`
-	Limiter      *Limiter        `json:"limiter,omitempty"`
-	Cache        *Cache          `json:"cache,omitempty"`
-	Request      *Request        `json:"request,omitempty"`
-	Components   *Components     `json:"components,omitempty"`
-	Templates    *Templates      `json:"templates,omitempty"`
-	Endpoints    []Endpoint      `json:"endpoints,omitempty"`
+	Comment      string                `json:"@comment,omitempty"`
+	Version      string                `json:"version,omitempty"`
+	HotReload    bool                  `json:"hot-reload,omitempty"`
+	Server       *Server               `json:"server,omitempty"`
+	Store        *Store                `json:"store,omitempty"`
+	Brokers      *Brokers              `json:"brokers,omitempty"`
+	Credentials  map[string]Credential `json:"credentials,omitempty"`
+	Timeout      vo.Duration           `json:"timeout,omitempty"`
+	Execution    *GopenExecution       `json:"execution,omitempty"`
+	SecurityCors *SecurityCors         `json:"security-cors,omitempty"`
+	Auth         *Auth                 `json:"auth,omitempty"`
+	Limiter      *Limiter              `json:"limiter,omitempty"`
+	Cache        *Cache                `json:"cache,omitempty"`
+	Admin        *Admin                `json:"admin,omitempty"`
+	Request      *Request              `json:"request,omitempty"`
+	Components   *Components           `json:"components,omitempty"`
+	Templates    *Templates            `json:"templates,omitempty"`
+	Endpoints    []Endpoint            `json:"endpoints,omitempty"`
+	Consumers    []Consumer            `json:"consumers,omitempty"`
+}
+
+// Admin exposes the administrative routes under path, they are always protected by the auth merged with the root one.
+type Admin struct {
+	Comment string `json:"@comment,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Auth    *Auth  `json:"auth,omitempty"`
 }
 
 type Server struct {
@@ -48,6 +61,23 @@ type Server struct {
 	IdleTimeout       *vo.Duration `json:"idle-timeout,omitempty"`
 	KeepAlive         *bool        `json:"keep-alive,omitempty"`
 	Client            *ClientPool  `json:"client,omitempty"`
+	GRPC              *ServerGRPC  `json:"grpc,omitempty"`
+	TLS               *ServerTLS   `json:"tls,omitempty"`
+}
+
+// ServerTLS terminates TLS on the HTTP listener, the certificate files are reloaded when they change on disk.
+type ServerTLS struct {
+	Comment      string             `json:"@comment,omitempty"`
+	CertFile     string             `json:"cert-file,omitempty"`
+	KeyFile      string             `json:"key-file,omitempty"`
+	ClientCAFile string             `json:"client-ca-file,omitempty"`
+	ClientAuth   enum.TLSClientAuth `json:"client-auth,omitempty"`
+}
+
+type ServerGRPC struct {
+	Comment     string   `json:"@comment,omitempty"`
+	Port        *int     `json:"port,omitempty"`
+	Descriptors []string `json:"descriptors,omitempty"`
 }
 
 type ClientPool struct {
@@ -58,6 +88,17 @@ type ClientPool struct {
 	IdleConnTimeout     *vo.Duration    `json:"idle-conn-timeout,omitempty"`
 	CircuitBreaker      *CircuitBreaker `json:"circuit-breaker,omitempty"`
 	Retry               *Retry          `json:"retry,omitempty"`
+	TLS                 []ClientTLS     `json:"tls,omitempty"`
+}
+
+// ClientTLS holds the client certificate presented and the CAs trusted on the connections to the backend hosts.
+type ClientTLS struct {
+	Comment    string   `json:"@comment,omitempty"`
+	Hosts      []string `json:"hosts,omitempty"`
+	CertFile   string   `json:"cert-file,omitempty"`
+	KeyFile    string   `json:"key-file,omitempty"`
+	CAFile     string   `json:"ca-file,omitempty"`
+	ServerName string   `json:"server-name,omitempty"`
 }
 
 type CircuitBreaker struct {
@@ -114,6 +155,7 @@ type RequestClientTransportHeadersResponse struct {
 	BackendCache    *bool `json:"backend-cache,omitempty"`
 	ExecutionStatus *bool `json:"execution-status,omitempty"`
 	Degradation     *bool `json:"degradation,omitempty"`
+	RateLimit       *bool `json:"rate-limit,omitempty"`
 }
 
 type RequestClientValue struct {
@@ -142,13 +184,109 @@ type Redis struct {
 	Password string `json:"password,omitempty"`
 }
 
+// Brokers holds the connection settings shared by every PUBLISHER backend of the same broker.
+type Brokers struct {
+	Comment     string             `json:"@comment,omitempty"`
+	Kafka       *BrokerKafka       `json:"kafka,omitempty"`
+	AMQP        *BrokerAMQP        `json:"amqp,omitempty"`
+	NATS        *BrokerNATS        `json:"nats,omitempty"`
+	RedisStream *BrokerRedisStream `json:"redis-stream,omitempty"`
+}
+
+// Credential declares how the gateway obtains the token sent to the backends that reference it by name.
+type Credential struct {
+	Comment string            `json:"@comment,omitempty"`
+	OAuth2  *CredentialOAuth2 `json:"oauth2,omitempty"`
+}
+
+// CredentialOAuth2 obtains the token through the OAuth2 client-credentials grant.
+type CredentialOAuth2 struct {
+	Comment       string       `json:"@comment,omitempty"`
+	TokenURL      string       `json:"token-url,omitempty"`
+	ClientID      string       `json:"client-id,omitempty"`
+	ClientSecret  string       `json:"client-secret,omitempty"`
+	Scopes        []string     `json:"scopes,omitempty"`
+	Audience      string       `json:"audience,omitempty"`
+	RefreshBefore *vo.Duration `json:"refresh-before,omitempty"`
+}
+
+type BrokerKafka struct {
+	Comment   string   `json:"@comment,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	ClientID  string   `json:"client-id,omitempty"`
+}
+
+type BrokerAMQP struct {
+	Comment string `json:"@comment,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+type BrokerNATS struct {
+	Comment   string `json:"@comment,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Name      string `json:"name,omitempty"`
+	JetStream bool   `json:"jetstream,omitempty"`
+}
+
+// Consumer receives the messages of a queue or topic and executes the referenced endpoint for each one of them.
+type Consumer struct {
+	Comment    string             `json:"@comment,omitempty"`
+	ID         string             `json:"id,omitempty"`
+	Broker     enum.BackendBroker `json:"broker,omitempty"`
+	Path       string             `json:"path,omitempty"`
+	Group      string             `json:"group,omitempty"`
+	Endpoint   ConsumerEndpoint   `json:"endpoint,omitempty"`
+	Retry      *ConsumerRetry     `json:"retry,omitempty"`
+	DeadLetter string             `json:"dead-letter,omitempty"`
+}
+
+type ConsumerEndpoint struct {
+	Path   string `json:"path,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+type ConsumerRetry struct {
+	Comment     string       `json:"@comment,omitempty"`
+	MaxAttempts *int         `json:"max-attempts,omitempty"`
+	Backoff     *vo.Duration `json:"backoff,omitempty"`
+}
+
+// BrokerRedisStream only holds the stream settings, the connection is the one declared in Store.
+type BrokerRedisStream struct {
+	Comment     string `json:"@comment,omitempty"`
+	MaxLen      int64  `json:"max-len,omitempty"`
+	Approximate bool   `json:"approximate,omitempty"`
+}
+
 type Cache struct {
-	OnlyIf   []string      `json:"only-if,omitempty"`
-	IgnoreIf []string      `json:"ignore-if,omitempty"`
-	Read     CacheDecision `json:"read,omitempty"`
-	Write    CacheDecision `json:"write,omitempty"`
-	Key      string        `json:"key,omitempty"`
-	TTL      vo.Duration   `json:"ttl,omitempty"`
+	OnlyIf               []string       `json:"only-if,omitempty"`
+	IgnoreIf             []string       `json:"ignore-if,omitempty"`
+	Read                 CacheDecision  `json:"read,omitempty"`
+	Write                CacheDecision  `json:"write,omitempty"`
+	Key                  string         `json:"key,omitempty"`
+	Tags                 []string       `json:"tags,omitempty"`
+	TTL                  vo.Duration    `json:"ttl,omitempty"`
+	StaleWhileRevalidate vo.Duration    `json:"stale-while-revalidate,omitempty"`
+	StaleIfError         vo.Duration    `json:"stale-if-error,omitempty"`
+	Coalesce             *CacheCoalesce `json:"coalesce,omitempty"`
+	HTTP                 *CacheHTTP     `json:"http,omitempty"`
+}
+
+type CacheCoalesce struct {
+	LockTTL vo.Duration `json:"lock-ttl,omitempty"`
+	Wait    vo.Duration `json:"wait,omitempty"`
+}
+
+type CacheHTTP struct {
+	TTL     enum.CacheHTTPTTL `json:"ttl,omitempty"`
+	NoCache *CacheDecision    `json:"no-cache,omitempty"`
+}
+
+type CacheInvalidate struct {
+	Comment  string   `json:"@comment,omitempty"`
+	Keys     []string `json:"keys,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 type CacheDecision struct {
@@ -157,8 +295,11 @@ type CacheDecision struct {
 }
 
 type Limiter struct {
-	Size *LimiterSize `json:"size,omitempty"`
-	Rate *LimiterRate `json:"rate,omitempty"`
+	Size              *LimiterSize        `json:"size,omitempty"`
+	Rate              *LimiterRate        `json:"rate,omitempty"`
+	Rates             []LimiterRate       `json:"rates,omitempty"`
+	Concurrency       *LimiterConcurrency `json:"concurrency,omitempty"`
+	GlobalConcurrency *LimiterConcurrency `json:"global-concurrency,omitempty"`
 }
 
 type LimiterSize struct {
@@ -171,10 +312,31 @@ type LimiterSize struct {
 }
 
 type LimiterRate struct {
-	OnlyIf   []string     `json:"only-if,omitempty"`
-	IgnoreIf []string     `json:"ignore-if,omitempty"`
-	Capacity *int         `json:"capacity,omitempty"`
-	Every    *vo.Duration `json:"every,omitempty"`
+	OnlyIf      []string                `json:"only-if,omitempty"`
+	IgnoreIf    []string                `json:"ignore-if,omitempty"`
+	Key         string                  `json:"key,omitempty"`
+	Capacity    *int                    `json:"capacity,omitempty"`
+	Every       *vo.Duration            `json:"every,omitempty"`
+	Distributed *LimiterRateDistributed `json:"distributed,omitempty"`
+}
+
+type LimiterRateDistributed struct {
+	Algorithm enum.LimiterAlgorithm `json:"algorithm,omitempty"`
+	OnFailure enum.LimiterOnFailure `json:"on-failure,omitempty"`
+	Timeout   *vo.Duration          `json:"timeout,omitempty"`
+}
+
+type LimiterConcurrency struct {
+	MaxInFlight  *int                        `json:"max-in-flight,omitempty"`
+	MaxQueue     *int                        `json:"max-queue,omitempty"`
+	QueueTimeout *vo.Duration                `json:"queue-timeout,omitempty"`
+	Adaptive     *LimiterConcurrencyAdaptive `json:"adaptive,omitempty"`
+}
+
+type LimiterConcurrencyAdaptive struct {
+	MaxLatency    *vo.Duration `json:"max-latency,omitempty"`
+	MaxGoroutines *int         `json:"max-goroutines,omitempty"`
+	Window        *vo.Duration `json:"window,omitempty"`
 }
 
 type SecurityCors struct {
@@ -186,6 +348,72 @@ type SecurityCors struct {
 	AllowCredentials bool     `json:"allow-credentials"`
 }
 
+type Auth struct {
+	Comment       string             `json:"@comment,omitempty"`
+	Enabled       *bool              `json:"enabled,omitempty"`
+	JWT           *AuthJWT           `json:"jwt,omitempty"`
+	Introspection *AuthIntrospection `json:"introspection,omitempty"`
+	APIKey        *AuthAPIKey        `json:"api-key,omitempty"`
+	Signature     *AuthSignature     `json:"signature,omitempty"`
+	Scopes        []string           `json:"scopes,omitempty"`
+}
+
+type AuthJWT struct {
+	Comment     string       `json:"@comment,omitempty"`
+	JWKSURL     string       `json:"jwks-url,omitempty"`
+	JWKSFile    string       `json:"jwks-file,omitempty"`
+	JWKSRefresh *vo.Duration `json:"jwks-refresh,omitempty"`
+	Issuer      string       `json:"issuer,omitempty"`
+	Audience    []string     `json:"audience,omitempty"`
+	Algorithms  []string     `json:"algorithms,omitempty"`
+	ClockSkew   *vo.Duration `json:"clock-skew,omitempty"`
+}
+
+type AuthIntrospection struct {
+	Comment       string       `json:"@comment,omitempty"`
+	URL           string       `json:"url,omitempty"`
+	ClientID      string       `json:"client-id,omitempty"`
+	ClientSecret  string       `json:"client-secret,omitempty"`
+	TokenTypeHint string       `json:"token-type-hint,omitempty"`
+	CacheTTL      *vo.Duration `json:"cache-ttl,omitempty"`
+}
+
+// AuthAPIKey looks up the key in keys, then in the JSON file and finally in the Redis declared as the global store.
+type AuthAPIKey struct {
+	Comment string            `json:"@comment,omitempty"`
+	Header  string            `json:"header,omitempty"`
+	Query   string            `json:"query,omitempty"`
+	Keys    []AuthAPIKeyEntry `json:"keys,omitempty"`
+	File    string            `json:"file,omitempty"`
+	Store   bool              `json:"store,omitempty"`
+}
+
+type AuthAPIKeyEntry struct {
+	Comment  string         `json:"@comment,omitempty"`
+	Key      string         `json:"key,omitempty"`
+	Consumer string         `json:"consumer,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// AuthSignature verifies the HMAC signature of inbound webhooks, the secret is read from the env var named by
+// secret-env so it stays out of the JSON.
+type AuthSignature struct {
+	Comment   string                  `json:"@comment,omitempty"`
+	Header    string                  `json:"header,omitempty"`
+	Prefix    string                  `json:"prefix,omitempty"`
+	Algorithm enum.HMACAlgorithm      `json:"algorithm,omitempty"`
+	Encoding  enum.SignatureEncoding  `json:"encoding,omitempty"`
+	SecretEnv string                  `json:"secret-env,omitempty"`
+	Canonical string                  `json:"canonical,omitempty"`
+	Timestamp *AuthSignatureTimestamp `json:"timestamp,omitempty"`
+}
+
+type AuthSignatureTimestamp struct {
+	Comment   string       `json:"@comment,omitempty"`
+	Header    string       `json:"header,omitempty"`
+	Tolerance *vo.Duration `json:"tolerance,omitempty"`
+}
+
 type EndpointExecution struct {
 	Comment     string             `json:"@comment,omitempty"`
 	Parallelism bool               `json:"parallelism,omitempty"`
@@ -221,18 +449,27 @@ type ComponentsBackends struct {
 type Endpoint struct {
 	Comment      string             `json:"@comment,omitempty"`
 	Execution    *EndpointExecution `json:"execution,omitempty"`
+	Protocol     enum.Protocol      `json:"protocol,omitempty"`
 	Path         string             `json:"path,omitempty"`
 	Method       string             `json:"method,omitempty"`
+	GRPC         *EndpointGRPC      `json:"grpc,omitempty"`
 	Timeout      vo.Duration        `json:"timeout,omitempty"`
 	SecurityCors *SecurityCors      `json:"security-cors,omitempty"`
+	Auth         *Auth              `json:"auth,omitempty"`
 	Limiter      *Limiter           `json:"limiter,omitempty"`
 	Cache        *Cache             `json:"cache,omitempty"`
+	Invalidate   *CacheInvalidate   `json:"invalidate,omitempty"`
 	Beforewares  []Backend          `json:"beforewares,omitempty"`
 	Backends     []Backend          `json:"backends,omitempty"`
 	Afterwares   []Backend          `json:"afterwares,omitempty"`
 	Response     *EndpointResponse  `json:"response,omitempty"`
 }
 
+type EndpointGRPC struct {
+	Comment string `json:"@comment,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
 type EndpointResponse struct {
 	Comment string `json:"@comment,omitempty"`
 
@@ -259,25 +496,61 @@ type Backend struct {
 	Template *Template        `json:"template,omitempty"`
 
 	// ---- HTTP ----
-	Cache *Cache `json:"cache,omitempty"`
+	Cache      *Cache           `json:"cache,omitempty"`
+	Invalidate *CacheInvalidate `json:"invalidate,omitempty"`
 
-	Hosts     []string         `json:"hosts,omitempty"`
-	Path      string           `json:"path,omitempty"`
-	Method    string           `json:"method,omitempty"`
-	Request   BackendRequest   `json:"request,omitempty"`
-	Propagate BackendPropagate `json:"propagate,omitempty"`
+	Hosts      []string         `json:"hosts,omitempty"`
+	Path       string           `json:"path,omitempty"`
+	Method     string           `json:"method,omitempty"`
+	Request    BackendRequest   `json:"request,omitempty"`
+	Propagate  BackendPropagate `json:"propagate,omitempty"`
+	Signing    *BackendSigning  `json:"signing,omitempty"`
+	Credential string           `json:"credential,omitempty"`
 
 	// ---- PUBLISHER ----
 	Broker enum.BackendBroker `json:"broker,omitempty"`
 
 	GroupID         string           `json:"group-id,omitempty"`
 	DeduplicationID string           `json:"deduplication-id,omitempty"`
+	RoutingKey      string           `json:"routing-key,omitempty"`
 	Delay           vo.Duration      `json:"delay,omitempty"`
 	Message         PublisherMessage `json:"message,omitempty"`
 
+	// ---- GRPC ----
+	Descriptors []string `json:"descriptors,omitempty"`
+
+	// ---- GRAPHQL ----
+	Query         string         `json:"query,omitempty"`
+	OperationName string         `json:"operation-name,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+
 	Response BackendResponse `json:"response,omitempty"`
 }
 
+// BackendSigning signs the final HTTP request, aws uses the default AWS credentials chain.
+type BackendSigning struct {
+	Comment string              `json:"@comment,omitempty"`
+	AWS     *BackendSigningAWS  `json:"aws,omitempty"`
+	HMAC    *BackendSigningHMAC `json:"hmac,omitempty"`
+}
+
+type BackendSigningAWS struct {
+	Comment string `json:"@comment,omitempty"`
+	Service string `json:"service,omitempty"`
+	Region  string `json:"region,omitempty"`
+}
+
+type BackendSigningHMAC struct {
+	Comment         string                 `json:"@comment,omitempty"`
+	Header          string                 `json:"header,omitempty"`
+	Prefix          string                 `json:"prefix,omitempty"`
+	Algorithm       enum.HMACAlgorithm     `json:"algorithm,omitempty"`
+	Encoding        enum.SignatureEncoding `json:"encoding,omitempty"`
+	SecretEnv       string                 `json:"secret-env,omitempty"`
+	Canonical       string                 `json:"canonical,omitempty"`
+	TimestampHeader string                 `json:"timestamp-header,omitempty"`
+}
+
 type BackendRequest struct {
 	Comment    string                    `json:"@comment,omitempty"`
 	Components *BackendRequestComponents `json:"components,omitempty"`
@@ -422,6 +695,17 @@ type BackendExecution struct {
 	On         []enum.ExecutionOn `json:"on,omitempty"`
 }
 
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
 type ErrorPayload struct {
 	ID        string    `json:"id,omitempty"`
 	File      string    `json:"file"`
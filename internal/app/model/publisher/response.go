@@ -12,4 +12,10 @@ type Body struct {
 	SequentialNumber string `json:"sequentialNumber,omitempty"`
 	DeduplicationID  string `json:"deduplicationId,omitempty"`
 	GroupID          string `json:"groupId,omitempty"`
+	Topic            string `json:"topic,omitempty"`
+	Partition        *int32 `json:"partition,omitempty"`
+	Offset           *int64 `json:"offset,omitempty"`
+	RoutingKey       string `json:"routingKey,omitempty"`
+	Stream           string `json:"stream,omitempty"`
+	Duplicate        bool   `json:"duplicate,omitempty"`
 }
@@ -0,0 +1,12 @@
+package rpc
+
+import "google.golang.org/grpc/codes"
+
+// Response is the reply of a unary gRPC call with the output message already encoded as JSON. Calls finished with
+// a status other than OK are also returned as a response, as the HTTP client does with the error status codes.
+type Response struct {
+	Code    codes.Code          `json:"code"`
+	Message string              `json:"message,omitempty"`
+	Header  map[string][]string `json:"header,omitempty"`
+	Body    []byte              `json:"body,omitempty"`
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// listenAndServeConsumers starts each consumer in background, they run until the shutdown of the server.
+func (h *http) listenAndServeConsumers() {
+	h.log.PrintInfo("Configuring consumers...")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.consumersCancel = cancel
+	h.consumersWait = &sync.WaitGroup{}
+
+	for i := range h.gopen.Consumers() {
+		consumer := &h.gopen.Consumers()[i]
+
+		h.log.PrintInfof("Registered consumer %s: %s --> \"%s\"", consumer.ID(), consumer.Broker(),
+			consumer.Endpoint().Path())
+
+		h.consumersWait.Add(1)
+		go func() {
+			defer h.consumersWait.Done()
+			err := h.consumerClient.Consume(ctx, consumer, h.buildConsumerHandler(consumer))
+			if checker.NonNil(err) {
+				h.log.PrintWarnf("Consumer %s stopped: %s", consumer.ID(), err)
+			}
+		}()
+	}
+}
+
+// shutdownConsumers stops receiving new messages and waits the in-flight ones until the ctx deadline.
+func (h *http) shutdownConsumers(ctx context.Context) {
+	if checker.IsNil(h.consumersCancel) {
+		return
+	}
+	h.consumersCancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		h.consumersWait.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+	}
+}
+
+// buildConsumerHandler executes the endpoint with its timeout, the message is only acknowledged when every backend
+// responded OK.
+func (h *http) buildConsumerHandler(consumer *vo.ConsumerConfig) app.ConsumerHandler {
+	return func(ctx context.Context, request *vo.EndpointRequest) (ok bool) {
+		defer func() {
+			if r := recover(); checker.NonNil(r) {
+				h.log.PrintError("Consumer", consumer.ID(), "panic:", r)
+				ok = false
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(ctx, consumer.Endpoint().Timeout().Time())
+		defer cancel()
+
+		response := h.endpointUseCase.Execute(ctx, dto.ExecuteEndpoint{
+			Gopen:    h.gopen,
+			Endpoint: consumer.Endpoint(),
+			Request:  request,
+		})
+		return response.Execution().AllOK()
+	}
+}
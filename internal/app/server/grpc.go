@@ -0,0 +1,118 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// listenAndServeGRPC starts the inbound gRPC listener in background, sharing the same use case and interceptors of
+// the HTTP server. Only endpoints with the grpc section are exposed.
+func (h *http) listenAndServeGRPC() {
+	h.log.PrintInfo("Configuring gRPC methods...")
+
+	h.buildGRPCRoutes()
+
+	listener, err := net.Listen("tcp", fmt.Sprint(":", h.gopen.Server().GRPC().Port()))
+	if checker.NonNil(err) {
+		panic(err)
+	}
+
+	h.grpc = h.grpcRouter.Engine()
+
+	h.log.PrintInfof("gRPC listening on %s", listener.Addr().String())
+
+	go func() {
+		if err := h.grpc.Serve(listener); checker.NonNil(err) {
+			h.log.PrintWarnf("gRPC server stopped: %s", err)
+		}
+	}()
+}
+
+// shutdownGRPC drains the in-flight calls until the ctx deadline, then closes the remaining ones.
+func (h *http) shutdownGRPC(ctx context.Context) {
+	if checker.IsNil(h.grpc) {
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		h.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		h.grpc.Stop()
+	}
+}
+
+func (h *http) buildGRPCRoutes() {
+	for _, endpoint := range h.gopen.Endpoints() {
+		if !endpoint.HasGRPC() {
+			continue
+		}
+
+		handles := h.buildGRPCEndpointHandles()
+		h.grpcRouter.Handle(h.gopen, &endpoint, handles...)
+
+		h.log.PrintInfof("Registered gRPC method with %s handles: %s --> \"%s\"", converter.ToString(len(handles)),
+			endpoint.GRPC().FullMethod(), endpoint.Path())
+	}
+}
+
+// buildGRPCEndpointHandles is the HTTP chain without keep-alive and CORS, which have no meaning over gRPC.
+func (h *http) buildGRPCEndpointHandles() []app.HandlerFunc {
+	return []app.HandlerFunc{
+		h.panicRecoveryInterceptor.Do,
+		h.timeoutInterceptor.Do,
+		h.logInterceptor.Do,
+		h.authInterceptor.Do,
+		h.limiterInterceptor.Do,
+		h.endpointController.Do,
+	}
+}
+
+// loadGRPCBackends resolves the methods of every gRPC backend, so that an unreadable descriptor set or an unknown
+// method fails the boot.
+func (h *http) loadGRPCBackends() {
+	for _, endpoint := range h.gopen.Endpoints() {
+		h.loadGRPCEndpointBackends(&endpoint)
+	}
+	for _, consumer := range h.gopen.Consumers() {
+		h.loadGRPCEndpointBackends(consumer.Endpoint())
+	}
+}
+
+func (h *http) loadGRPCEndpointBackends(endpoint *vo.EndpointConfig) {
+	for _, backend := range endpoint.Backends() {
+		if !backend.IsGRPC() {
+			continue
+		} else if err := h.grpcClient.Load(backend.GRPC()); checker.NonNil(err) {
+			panic(err)
+		}
+	}
+}
@@ -22,6 +22,10 @@ import (
 	"net"
 	nethttp "net/http"
 	"os"
+	"strings"
+	"sync"
+
+	netgrpc "google.golang.org/grpc"
 
 	"github.com/tech4works/checker"
 	"github.com/tech4works/converter"
@@ -39,17 +43,28 @@ import (
 
 type http struct {
 	net                      *nethttp.Server
+	grpc                     *netgrpc.Server
 	gopen                    *vo.GopenConfig
 	log                      app.BootLog
 	router                   app.Router
+	grpcRouter               app.GRPCRouter
+	grpcClient               app.GRPCClient
+	consumerClient           app.ConsumerClient
+	consumersCancel          context.CancelFunc
+	consumersWait            *sync.WaitGroup
+	endpointUseCase          usecase.Endpoint
 	panicRecoveryInterceptor interceptor.PanicRecovery
 	logInterceptor           interceptor.Log
 	securityCorsInterceptor  interceptor.SecurityCors
+	authInterceptor          interceptor.Auth
 	timeoutInterceptor       interceptor.Timeout
 	limiterInterceptor       interceptor.Limiter
+	limiterService           service.Limiter
 	keepAliveInterceptor     interceptor.KeepAlive
 	staticController         controller.Static
+	adminController          controller.Admin
 	endpointController       controller.Endpoint
+	webSocketController      controller.WebSocket
 }
 
 type HTTP interface {
@@ -61,8 +76,17 @@ func New(
 	gopen *dto.Gopen,
 	log app.BootLog,
 	router app.Router,
+	grpcRouter app.GRPCRouter,
 	httpClient app.HTTPClient,
 	publisherClient app.PublisherClient,
+	webSocketClient app.WebSocketClient,
+	grpcClient app.GRPCClient,
+	consumerClient app.ConsumerClient,
+	jwtValidator app.JWTValidator,
+	tokenIntrospector app.TokenIntrospector,
+	apiKeyResolver app.APIKeyResolver,
+	signatureVerifier app.SignatureVerifier,
+	credentialProvider app.CredentialProvider,
 	middlewareLog app.MiddlewareLog,
 	endpointLog app.EndpointLog,
 	backendLog app.BackendLog,
@@ -70,6 +94,7 @@ func New(
 	jsonPath domain.JSONPath,
 	converter domain.Converter,
 	store domain.Store,
+	rateStore domain.RateStore,
 	nomenclature domain.Nomenclature,
 ) HTTP {
 	log.PrintInfo("Building domain...")
@@ -85,7 +110,7 @@ func New(
 
 	buildPipelineService := service.NewBuildPipeline(modifierService, joinService, mapperService, projectorService,
 		omitterService, nomenclatureService, contentService, aggregatorService, dynamicValueService)
-	limiterService := service.NewLimiter()
+	limiterService := service.NewLimiter(dynamicValueService, rateStore)
 	securityCorsService := service.NewSecurityCors(dynamicValueService)
 	cacheService := service.NewCache(dynamicValueService, store)
 
@@ -96,18 +121,25 @@ func New(
 
 	log.PrintInfo("Building use cases...")
 	endpointUseCase := usecase.NewEndpoint(dynamicValueService, cacheService, backendRequestFactory,
-		backendResponseFactory, endpointResponseFactory, httpClient, publisherClient, endpointLog, backendLog)
+		backendResponseFactory, endpointResponseFactory, httpClient, credentialProvider, publisherClient, grpcClient,
+		endpointLog, backendLog)
+	webSocketUseCase := usecase.NewWebSocket(backendRequestFactory, backendResponseFactory, endpointResponseFactory,
+		webSocketClient, endpointLog, backendLog)
+	cacheUseCase := usecase.NewCache(cacheService)
 
 	log.PrintInfo("Building middlewares...")
 	panicRecoveryInterceptor := interceptor.NewPanicRecovery(middlewareLog)
 	logInterceptor := interceptor.NewLog(httpLog)
 	securityCorsInterceptor := interceptor.NewSecurityCors(securityCorsService)
 	timeoutInterceptor := interceptor.NewTimeout()
+	authInterceptor := interceptor.NewAuth(jwtValidator, tokenIntrospector, apiKeyResolver, signatureVerifier)
 	limiterInterceptor := interceptor.NewLimiter(limiterService)
 
 	log.PrintInfo("Building controllers...")
 	staticController := controller.NewStatic(gopen)
+	adminController := controller.NewAdmin(cacheUseCase)
 	endpointController := controller.NewEndpoint(endpointUseCase)
+	webSocketController := controller.NewWebSocket(webSocketUseCase)
 
 	log.PrintInfo("Building value objects...")
 	gopenConfig := factory.BuildGopen(gopen)
@@ -117,14 +149,22 @@ func New(
 		gopen:                    gopenConfig,
 		log:                      log,
 		router:                   router,
+		grpcRouter:               grpcRouter,
+		grpcClient:               grpcClient,
+		consumerClient:           consumerClient,
+		endpointUseCase:          endpointUseCase,
 		panicRecoveryInterceptor: panicRecoveryInterceptor,
 		logInterceptor:           logInterceptor,
 		timeoutInterceptor:       timeoutInterceptor,
 		limiterInterceptor:       limiterInterceptor,
+		limiterService:           limiterService,
 		keepAliveInterceptor:     keepAliveInterceptor,
 		securityCorsInterceptor:  securityCorsInterceptor,
+		authInterceptor:          authInterceptor,
 		staticController:         staticController,
+		adminController:          adminController,
 		endpointController:       endpointController,
+		webSocketController:      webSocketController,
 	}
 }
 
@@ -136,6 +176,7 @@ func (h *http) ListenAndServe() {
 
 	h.buildStaticRoutes()
 	h.buildRoutes()
+	h.loadGRPCBackends()
 
 	serverConfig := h.gopen.Server()
 
@@ -181,13 +222,50 @@ func (h *http) ListenAndServe() {
 		panic(err)
 	}
 
+	if serverConfig.HasGRPC() {
+		h.listenAndServeGRPC()
+	}
+
+	if checker.IsNotEmpty(h.gopen.Consumers()) {
+		h.listenAndServeConsumers()
+	}
+
+	if serverConfig.HasTLS() {
+		h.listenAndServeTLS(listener)
+		return
+	}
+
 	h.log.SkipLine()
 	h.log.PrintTitle(fmt.Sprintf("LISTEN AND SERVE %s", listener.Addr().String()))
 
 	h.net.Serve(listener)
 }
 
+// listenAndServeTLS terminates TLS on the listener, the certificate and key are served by the reloader so the empty
+// files informed to ServeTLS are never read.
+func (h *http) listenAndServeTLS(listener net.Listener) {
+	tlsConfig := h.gopen.Server().TLS()
+
+	reloader, err := newTLSReloader(tlsConfig, h.log)
+	if checker.NonNil(err) {
+		panic(err)
+	}
+	h.net.TLSConfig = reloader.TLSConfig()
+
+	h.log.PrintInfof("Server tls config: cert-file=%s client-auth=%s", tlsConfig.CertFile(), tlsConfig.ClientAuth())
+
+	h.log.SkipLine()
+	h.log.PrintTitle(fmt.Sprintf("LISTEN AND SERVE TLS %s", listener.Addr().String()))
+
+	h.net.ServeTLS(listener, "", "")
+}
+
 func (h *http) Shutdown(ctx context.Context) error {
+	defer h.limiterService.Close()
+
+	h.shutdownGRPC(ctx)
+	h.shutdownConsumers(ctx)
+
 	if checker.IsNil(h.net) {
 		return nil
 	}
@@ -196,7 +274,7 @@ func (h *http) Shutdown(ctx context.Context) error {
 
 func (h *http) buildRoutes() {
 	for _, endpoint := range h.gopen.Endpoints() {
-		handles := h.buildEndpointHandles()
+		handles := h.buildEndpointHandles(&endpoint)
 		h.router.Handle(h.gopen, &endpoint, handles...)
 
 		h.log.PrintInfof("Registered route with %s handles: %s", converter.ToString(len(handles)), endpoint.Resume())
@@ -211,6 +289,10 @@ func (h *http) buildStaticRoutes() {
 
 	versionEndpoint := h.buildStaticVersionRoute()
 	h.log.PrintInfof(formatLog, versionEndpoint.Method(), versionEndpoint.Path())
+
+	if h.gopen.HasAdmin() {
+		h.buildAdminPurgeCacheRoute()
+	}
 }
 
 func (h *http) buildStaticPingRoute() *vo.EndpointConfig {
@@ -225,6 +307,29 @@ func (h *http) buildStaticVersionRoute() *vo.EndpointConfig {
 	return &endpoint
 }
 
+func (h *http) buildAdminPurgeCacheRoute() {
+	admin := h.gopen.Admin()
+	endpoint := vo.NewEndpointConfigAdmin(fmt.Sprintf("%s/cache", strings.TrimSuffix(admin.Path(), "/")),
+		nethttp.MethodDelete, admin.Auth())
+	h.buildAdminRoute(&endpoint, h.adminController.PurgeCache)
+}
+
+func (h *http) buildAdminRoute(endpointAdmin *vo.EndpointConfig, handler app.HandlerFunc) {
+	handles := []app.HandlerFunc{
+		h.keepAliveInterceptor.Do,
+		h.timeoutInterceptor.Do,
+		h.panicRecoveryInterceptor.Do,
+		h.logInterceptor.Do,
+		h.authInterceptor.Do,
+		h.limiterInterceptor.Do,
+		handler,
+	}
+	h.router.Handle(h.gopen, endpointAdmin, handles...)
+
+	h.log.PrintInfof("Registered route with %s handles: %s --> \"%s\"", converter.ToString(len(handles)),
+		endpointAdmin.Method(), endpointAdmin.Path())
+}
+
 func (h *http) buildStaticRoute(endpointStatic *vo.EndpointConfig, handler app.HandlerFunc) {
 	keepAliveHandler := h.keepAliveInterceptor.Do
 	timeoutHandler := h.timeoutInterceptor.Do
@@ -234,14 +339,31 @@ func (h *http) buildStaticRoute(endpointStatic *vo.EndpointConfig, handler app.H
 	h.router.Handle(h.gopen, endpointStatic, keepAliveHandler, timeoutHandler, panicHandler, logHandler, limiterHandler, handler)
 }
 
-func (h *http) buildEndpointHandles() []app.HandlerFunc {
+func (h *http) buildEndpointHandles(endpoint *vo.EndpointConfig) []app.HandlerFunc {
+	if endpoint.IsWebSocket() {
+		return h.buildWebSocketEndpointHandles()
+	}
 	return []app.HandlerFunc{
 		h.keepAliveInterceptor.Do,
 		h.panicRecoveryInterceptor.Do,
 		h.timeoutInterceptor.Do,
 		h.logInterceptor.Do,
 		h.securityCorsInterceptor.Do,
+		h.authInterceptor.Do,
 		h.limiterInterceptor.Do,
 		h.endpointController.Do,
 	}
 }
+
+// buildWebSocketEndpointHandles drops keep-alive and timeout, the session lifetime is controlled by the peers and
+// not by the endpoint timeout.
+func (h *http) buildWebSocketEndpointHandles() []app.HandlerFunc {
+	return []app.HandlerFunc{
+		h.panicRecoveryInterceptor.Do,
+		h.logInterceptor.Do,
+		h.securityCorsInterceptor.Do,
+		h.authInterceptor.Do,
+		h.limiterInterceptor.Do,
+		h.webSocketController.Do,
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// tlsCheckInterval limits how often the certificate files are checked for changes during the handshakes.
+const tlsCheckInterval = time.Second
+
+// tlsReloader serves the certificate and the client CA bundle of the listener, reading the files again whenever their
+// modification time changes. A file that fails to load keeps the previous version in use.
+type tlsReloader struct {
+	config      *vo.ServerTLSConfig
+	log         app.BootLog
+	mutex       sync.RWMutex
+	tlsConfig   *tls.Config
+	modTime     time.Time
+	lastChecked time.Time
+}
+
+func newTLSReloader(config *vo.ServerTLSConfig, log app.BootLog) (*tlsReloader, error) {
+	reloader := &tlsReloader{
+		config: config,
+		log:    log,
+	}
+
+	modTime, err := reloader.readModTime()
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	err = reloader.load(modTime)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// TLSConfig returns the listener config, each handshake receives the config of the latest files loaded.
+func (t *tlsReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			t.reloadIfChanged()
+
+			t.mutex.RLock()
+			defer t.mutex.RUnlock()
+			return t.tlsConfig, nil
+		},
+	}
+}
+
+func (t *tlsReloader) reloadIfChanged() {
+	t.mutex.RLock()
+	recentlyChecked := checker.IsLessThan(time.Since(t.lastChecked), tlsCheckInterval)
+	t.mutex.RUnlock()
+	if recentlyChecked {
+		return
+	}
+
+	t.mutex.Lock()
+	t.lastChecked = time.Now()
+	t.mutex.Unlock()
+
+	modTime, err := t.readModTime()
+	if checker.NonNil(err) {
+		t.log.PrintWarnf("Error to check server tls files: %s", err)
+		return
+	}
+
+	t.mutex.RLock()
+	changed := modTime.After(t.modTime)
+	t.mutex.RUnlock()
+	if !changed {
+		return
+	}
+
+	if err = t.load(modTime); checker.NonNil(err) {
+		t.log.PrintWarnf("Error to reload server tls files: %s", err)
+		return
+	}
+	t.log.PrintInfo("Server tls files reloaded!")
+}
+
+func (t *tlsReloader) load(modTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(t.config.CertFile(), t.config.KeyFile())
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "server tls failed: op=load-certificate cert-file=%s key-file=%s",
+			t.config.CertFile(), t.config.KeyFile())
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+		NextProtos:   []string{"h2", "http/1.1"},
+		ClientAuth:   t.clientAuthType(),
+	}
+
+	if t.config.HasClientCAFile() {
+		pem, err := os.ReadFile(t.config.ClientCAFile())
+		if checker.NonNil(err) {
+			return errors.Inheritf(err, "server tls failed: op=read-client-ca client-ca-file=%s",
+				t.config.ClientCAFile())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.Newf("server tls failed: op=parse-client-ca client-ca-file=%s no certificate found",
+				t.config.ClientCAFile())
+		}
+		tlsConfig.ClientCAs = pool
+	}
+
+	t.mutex.Lock()
+	t.tlsConfig = tlsConfig
+	t.modTime = modTime
+	t.mutex.Unlock()
+
+	return nil
+}
+
+// readModTime returns the latest modification time among the configured files.
+func (t *tlsReloader) readModTime() (time.Time, error) {
+	files := []string{t.config.CertFile(), t.config.KeyFile()}
+	if t.config.HasClientCAFile() {
+		files = append(files, t.config.ClientCAFile())
+	}
+
+	var modTime time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if checker.NonNil(err) {
+			return time.Time{}, errors.Inheritf(err, "server tls failed: op=stat file=%s", file)
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func (t *tlsReloader) clientAuthType() tls.ClientAuthType {
+	switch t.config.ClientAuth() {
+	case enum.TLSClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven
+	case enum.TLSClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package usecase
+
+import (
+	"context"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/service"
+)
+
+type cacheUseCase struct {
+	cacheService service.Cache
+}
+
+type Cache interface {
+	Purge(ctx context.Context, keys, patterns, tags []string) error
+}
+
+func NewCache(cacheService service.Cache) Cache {
+	return cacheUseCase{
+		cacheService: cacheService,
+	}
+}
+
+func (c cacheUseCase) Purge(ctx context.Context, keys, patterns, tags []string) error {
+	return c.cacheService.Purge(ctx, keys, patterns, tags)
+}
@@ -17,15 +17,20 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/tech4works/checker"
 	"github.com/tech4works/errors"
@@ -41,6 +46,12 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
 )
 
+const coalesceReadInterval = 50 * time.Millisecond
+
+// staleIfErrorDeadlineReserve divides the remaining time of an execution under stale-if-error, the resulting part is
+// reserved to serve the stale entry.
+const staleIfErrorDeadlineReserve = 10
+
 type endpointUseCase struct {
 	dynamicValueService     service.DynamicValue
 	cacheService            service.Cache
@@ -48,9 +59,13 @@ type endpointUseCase struct {
 	backendResponseFactory  factory.BackendResponse
 	endpointResponseFactory factory.EndpointResponse
 	httpClient              app.HTTPClient
+	credentialProvider      app.CredentialProvider
 	publishClient           app.PublisherClient
+	grpcClient              app.GRPCClient
 	endpointLog             app.EndpointLog
 	backendLog              app.BackendLog
+	revalidating            *sync.Map
+	coalescing              *singleflight.Group
 }
 
 type backendExecResult struct {
@@ -59,6 +74,12 @@ type backendExecResult struct {
 	response *vo.BackendResponse
 }
 
+// coalesceResult is the result of an execution shared by the concurrent requests of a cache key, only when cacheable.
+type coalesceResult struct {
+	value     any
+	cacheable bool
+}
+
 type Endpoint interface {
 	Execute(ctx context.Context, executeData dto.ExecuteEndpoint) *vo.EndpointResponse
 }
@@ -70,7 +91,9 @@ func NewEndpoint(
 	backendResponseFactory factory.BackendResponse,
 	endpointResponseFactory factory.EndpointResponse,
 	httpClient app.HTTPClient,
+	credentialProvider app.CredentialProvider,
 	publishClient app.PublisherClient,
+	grpcClient app.GRPCClient,
 	endpointLog app.EndpointLog,
 	backendLog app.BackendLog,
 ) Endpoint {
@@ -81,34 +104,114 @@ func NewEndpoint(
 		backendResponseFactory:  backendResponseFactory,
 		endpointResponseFactory: endpointResponseFactory,
 		httpClient:              httpClient,
+		credentialProvider:      credentialProvider,
 		publishClient:           publishClient,
+		grpcClient:              grpcClient,
 		endpointLog:             endpointLog,
 		backendLog:              backendLog,
+		revalidating:            &sync.Map{},
+		coalescing:              &singleflight.Group{},
 	}
 }
 
 func (e endpointUseCase) Execute(ctx context.Context, executeData dto.ExecuteEndpoint) *vo.EndpointResponse {
-	cacheResponse := e.readEndpointResponseOnCacheIfNeeded(ctx, executeData)
-	if checker.NonNil(cacheResponse) {
-		return cacheResponse
+	if executeData.Request.IsConsumer() {
+		return e.executeEndpointWithoutCache(ctx, executeData)
 	}
 
-	history, aborted := e.executeAllBackends(ctx, executeData, executeData.Endpoint.Backends())
+	cacheEntry := e.readEndpointResponseOnCacheIfNeeded(ctx, executeData)
+	if checker.NonNil(cacheEntry) && cacheEntry.IsFresh() {
+		return cacheEntry.Response()
+	} else if checker.NonNil(cacheEntry) && cacheEntry.IsStaleWhileRevalidate() {
+		e.revalidateEndpointResponseOnCache(ctx, executeData, cacheEntry.Response())
+		return cacheEntry.Response()
+	}
 
-	var response *vo.EndpointResponse
-	if aborted {
-		response = e.endpointResponseFactory.BuildAbortedResponse(history)
-	} else {
-		response = e.buildEndpointResponse(ctx, executeData, history)
+	executeCtx := ctx
+	if checker.NonNil(cacheEntry) && cacheEntry.IsStaleIfError() {
+		var cancel context.CancelFunc
+		executeCtx, cancel = e.withStaleIfErrorDeadline(ctx)
+		defer cancel()
 	}
 
-	e.writeEndpointResponseOnCacheIfNeeded(ctx, executeData, history, response)
+	response := e.executeEndpointOnCacheMiss(executeCtx, executeData, cacheEntry)
+	if checker.NonNil(cacheEntry) && cacheEntry.IsStaleIfError() && e.isStaleIfErrorStatus(response.Status()) {
+		e.endpointLog.PrintWarnf(executeData, "serving stale endpoint response cache: status=%s", response.Status())
+		return cacheEntry.Response()
+	}
 
 	return response
 }
 
-func (e endpointUseCase) readEndpointResponseOnCacheIfNeeded(ctx context.Context, executeData dto.ExecuteEndpoint,
+// executeEndpointWithoutCache runs the backends without reading, writing or coalescing the endpoint cache, a message
+// consumed is only acknowledged when it was really delivered to the backends.
+func (e endpointUseCase) executeEndpointWithoutCache(ctx context.Context, executeData dto.ExecuteEndpoint,
+) *vo.EndpointResponse {
+	response, history := e.executeEndpoint(ctx, executeData, nil)
+	e.invalidateEndpointCacheIfNeeded(ctx, executeData, history, response)
+	return response
+}
+
+func (e endpointUseCase) executeEndpointOnCacheMiss(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	cacheEntry *vo.EndpointCacheEntry,
 ) *vo.EndpointResponse {
+	read := func(ctx context.Context) (any, bool) {
+		entry := e.readEndpointResponseOnCacheIfNeeded(ctx, executeData)
+		if checker.IsNil(entry) || !entry.IsFresh() {
+			return nil, false
+		}
+		return entry.Response(), true
+	}
+	var previous *vo.EndpointResponse
+	if checker.NonNil(cacheEntry) {
+		previous = cacheEntry.Response()
+	}
+	execute := func(ctx context.Context) (any, bool) {
+		response, history := e.executeEndpoint(ctx, executeData, previous)
+
+		stored := false
+		if checker.IsNil(cacheEntry) || !cacheEntry.IsStaleIfError() || !e.isStaleIfErrorStatus(response.Status()) {
+			stored = e.writeEndpointResponseOnCacheIfNeeded(ctx, executeData, history, response)
+		}
+		e.invalidateEndpointCacheIfNeeded(ctx, executeData, history, response)
+		return response, stored
+	}
+
+	result := e.coalesceOnCache(ctx, executeData, executeData.Endpoint.Cache(), nil, read, execute)
+	response, _ := result.(*vo.EndpointResponse)
+	return response
+}
+
+func (e endpointUseCase) executeEndpoint(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	previous *vo.EndpointResponse,
+) (*vo.EndpointResponse, *aggregate.History) {
+	history, aborted := e.executeAllBackends(ctx, executeData, executeData.Endpoint.Backends())
+	if aborted {
+		return e.endpointResponseFactory.BuildAbortedResponse(history), history
+	}
+	response := e.buildEndpointResponse(ctx, executeData, history)
+	if executeData.Endpoint.HasCache() && executeData.Endpoint.Cache().HasHTTP() && response.Status().OK() {
+		response = response.WithValidators(e.isUnmodifiedBackendResponse(history, response), previous, time.Now())
+	}
+	return response, history
+}
+
+// isUnmodifiedBackendResponse returns true when the endpoint response carries the payload of a single backend as
+// received, so the validators of that backend still describe it.
+func (e endpointUseCase) isUnmodifiedBackendResponse(history *aggregate.History, response *vo.EndpointResponse) bool {
+	backendResponse := history.SingleFinalResponse()
+	if checker.IsNil(backendResponse) || !backendResponse.HasBody() || !response.HasPayload() {
+		return false
+	}
+	return bytes.Equal(backendResponse.Payload().RawBytes(), response.Payload().RawBytes())
+}
+
+func (e endpointUseCase) readEndpointResponseOnCacheIfNeeded(ctx context.Context, executeData dto.ExecuteEndpoint,
+) *vo.EndpointCacheEntry {
 	if !executeData.Endpoint.HasCache() || !executeData.Endpoint.AllowCache() {
 		return nil
 	}
@@ -118,9 +221,199 @@ func (e endpointUseCase) readEndpointResponseOnCacheIfNeeded(ctx context.Context
 	if checker.NonNil(err) {
 		e.endpointLog.PrintWarnf(executeData, "error to read endpoint response cache: %v", err)
 		return nil
+	} else if endpointCacheEntry.IsZero() {
+		return nil
+	}
+
+	return &endpointCacheEntry
+}
+
+func (e endpointUseCase) revalidateEndpointResponseOnCache(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	previous *vo.EndpointResponse,
+) {
+	e.revalidateOnCache(ctx, executeData, executeData.Endpoint.Cache(), nil, func(ctx context.Context) {
+		response, history := e.executeEndpoint(ctx, executeData, previous)
+		if e.isStaleIfErrorStatus(response.Status()) {
+			return
+		}
+		e.writeEndpointResponseOnCacheIfNeeded(ctx, executeData, history, response)
+	})
+}
+
+func (e endpointUseCase) revalidateBackendResponseOnCache(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+) {
+	e.revalidateOnCache(ctx, executeData, backend.Cache(), history, func(ctx context.Context) {
+		response := e.executeBackendByKind(ctx, executeData, backend, time.Now(), history)
+		if checker.IsNil(response) || e.isStaleIfErrorStatus(response.Status()) {
+			return
+		}
+		e.writeBackendResponseOnCacheIfNeeded(ctx, executeData, backend, history, response)
+	})
+}
+
+// revalidateOnCache runs the refresh of a stale entry in background, detached from the request that served it, and
+// only one refresh per cache key runs at a time in the instance.
+func (e endpointUseCase) revalidateOnCache(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	config *vo.CacheConfig,
+	history *aggregate.History,
+	revalidate func(ctx context.Context),
+) {
+	key, err := e.cacheService.Key(config, executeData.Request, history)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to revalidate %s cache: %v", config.Kind(), err)
+		return
+	} else if _, loaded := e.revalidating.LoadOrStore(key, struct{}{}); loaded {
+		return
 	}
 
-	return endpointCacheEntry.Response()
+	go func() {
+		defer e.revalidating.Delete(key)
+		defer func() {
+			if r := recover(); checker.NonNil(r) {
+				e.endpointLog.PrintWarnf(executeData, "panic to revalidate %s cache key=%s: %v", config.Kind(), key, r)
+			}
+		}()
+
+		revalidateCtx, cancel := e.detachContext(ctx, executeData)
+		defer cancel()
+
+		if config.HasCoalesce() {
+			token, locked, err := e.cacheService.Lock(revalidateCtx, config, key)
+			if checker.NonNil(err) || !locked {
+				return
+			}
+			defer e.unlockOnCache(revalidateCtx, executeData, key, token)
+		}
+
+		revalidate(revalidateCtx)
+	}()
+}
+
+// coalesceOnCache runs the execution of a cache miss once per cache key, the concurrent requests in the instance wait
+// for it and share its result when it is cacheable, otherwise they execute by themselves. Across replicas, the store
+// lock elects the one executing, the others poll the cache until the entry is written or the wait expires, then they
+// execute by themselves.
+func (e endpointUseCase) coalesceOnCache(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	config *vo.CacheConfig,
+	history *aggregate.History,
+	read func(ctx context.Context) (any, bool),
+	execute func(ctx context.Context) (any, bool),
+) any {
+	if checker.IsNil(config) || !config.HasCoalesce() {
+		result, _ := execute(ctx)
+		return result
+	}
+
+	shouldRead, err := e.cacheService.ShouldRead(config, executeData.Request, history)
+	if checker.NonNil(err) || !shouldRead {
+		result, _ := execute(ctx)
+		return result
+	}
+
+	key, err := e.cacheService.Key(config, executeData.Request, history)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to coalesce %s cache: %v", config.Kind(), err)
+		result, _ := execute(ctx)
+		return result
+	}
+
+	leader := false
+	shared, _, _ := e.coalescing.Do(key, func() (any, error) {
+		leader = true
+
+		coalesceCtx, cancel := e.detachContext(ctx, executeData)
+		defer cancel()
+
+		return e.executeOnCacheLock(coalesceCtx, executeData, config, key, read, execute), nil
+	})
+
+	coalesced := shared.(coalesceResult)
+	if !leader && !coalesced.cacheable {
+		result, _ := execute(ctx)
+		return result
+	}
+	return coalesced.value
+}
+
+func (e endpointUseCase) executeOnCacheLock(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	config *vo.CacheConfig,
+	key string,
+	read func(ctx context.Context) (any, bool),
+	execute func(ctx context.Context) (any, bool),
+) coalesceResult {
+	token, locked, err := e.cacheService.Lock(ctx, config, key)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to coalesce %s cache: %v", config.Kind(), err)
+		return newCoalesceResult(execute(ctx))
+	} else if locked {
+		defer e.unlockOnCache(ctx, executeData, key, token)
+		return newCoalesceResult(execute(ctx))
+	}
+
+	ticker := time.NewTicker(coalesceReadInterval)
+	defer ticker.Stop()
+
+	wait := time.NewTimer(config.Coalesce().Wait())
+	defer wait.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return newCoalesceResult(execute(ctx))
+		case <-wait.C:
+			return newCoalesceResult(execute(ctx))
+		case <-ticker.C:
+			if result, ok := read(ctx); ok {
+				return newCoalesceResult(result, true)
+			}
+		}
+	}
+}
+
+func newCoalesceResult(value any, cacheable bool) coalesceResult {
+	return coalesceResult{value: value, cacheable: cacheable}
+}
+
+func (e endpointUseCase) unlockOnCache(ctx context.Context, executeData dto.ExecuteEndpoint, key, token string) {
+	err := e.cacheService.Unlock(ctx, key, token)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to unlock cache: %v", err)
+	}
+}
+
+// detachContext keeps the values of the request context but not its cancellation, so the work shared with other
+// requests or done in background is bounded only by the endpoint timeout.
+func (e endpointUseCase) detachContext(ctx context.Context, executeData dto.ExecuteEndpoint) (context.Context,
+	context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), executeData.Endpoint.Timeout().Time())
+}
+
+// withStaleIfErrorDeadline ends the execution before the deadline of ctx, keeping a part of the remaining time to
+// serve the stale entry, otherwise the endpoint timeout would answer first.
+func (e endpointUseCase) withStaleIfErrorDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-time.Until(deadline)/staleIfErrorDeadlineReserve))
+}
+
+// isStaleIfErrorStatus returns true when the execution failed by the server side or timed out, cases in which a stale
+// entry inside the stale-if-error window is served instead.
+func (e endpointUseCase) isStaleIfErrorStatus(status vo.ResponseStatus) bool {
+	return status.ServerError() || checker.Equals(status.Value(), enum.ResponseStatusBadGateway)
 }
 
 func (e endpointUseCase) executeAllBackends(
@@ -162,6 +455,7 @@ func (e endpointUseCase) executeAllBackends(
 			close(committed[r.i])
 		}
 		e.writeBackendResponseOnCacheIfNeeded(seqCtx, executeData, r.backend, history, r.response)
+		e.invalidateBackendCacheIfNeeded(seqCtx, executeData, r.backend, history, r.response)
 	}
 
 	pollAbort := func() (backendExecResult, bool) {
@@ -268,19 +562,76 @@ func (e endpointUseCase) executeBackend(
 		return
 	}
 
-	cacheBackendResponse := e.readBackendResponseOnCacheIfNeeded(ctx, executeData, backend, startTime, history)
-	if checker.NonNil(cacheBackendResponse) {
-		backendResponse = cacheBackendResponse
+	cacheEntry := e.readBackendResponseOnCacheIfNeeded(ctx, executeData, backend, history)
+	if checker.NonNil(cacheEntry) && cacheEntry.IsFresh() {
+		backendResponse = cacheEntry.Response(time.Since(startTime))
 		return
+	} else if checker.NonNil(cacheEntry) && cacheEntry.IsStaleWhileRevalidate() {
+		e.revalidateBackendResponseOnCache(ctx, executeData, backend, history)
+		backendResponse = cacheEntry.Response(time.Since(startTime))
+		return
+	}
+
+	if checker.NonNil(cacheEntry) && cacheEntry.IsStaleIfError() {
+		var staleIfErrorCancel context.CancelFunc
+		ctx, staleIfErrorCancel = e.withStaleIfErrorDeadline(ctx)
+		defer staleIfErrorCancel()
+	}
+
+	backendResponse = e.executeBackendOnCacheMiss(ctx, executeData, backend, startTime, history)
+	if checker.NonNil(cacheEntry) && cacheEntry.IsStaleIfError() && e.isStaleIfErrorStatus(backendResponse.Status()) {
+		e.backendLog.PrintWarnf(executeData, backend, "serving stale backend response cache: status=%s",
+			backendResponse.Status())
+		backendResponse = cacheEntry.Response(time.Since(startTime))
 	}
+	return
+}
 
+func (e endpointUseCase) executeBackendOnCacheMiss(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	history *aggregate.History,
+) *vo.BackendResponse {
+	if !backend.HasCache() || !backend.AllowCache() {
+		return e.executeBackendByKind(ctx, executeData, backend, startTime, history)
+	}
+
+	read := func(ctx context.Context) (any, bool) {
+		entry := e.readBackendResponseOnCacheIfNeeded(ctx, executeData, backend, history)
+		if checker.IsNil(entry) || !entry.IsFresh() {
+			return nil, false
+		}
+		return entry.Response(time.Since(startTime)), true
+	}
+	execute := func(ctx context.Context) (any, bool) {
+		backendResponse := e.executeBackendByKind(ctx, executeData, backend, startTime, history)
+		_, cacheable := e.buildBackendCacheConfigToWrite(executeData, backend, history, backendResponse)
+		return backendResponse, cacheable
+	}
+
+	result := e.coalesceOnCache(ctx, executeData, backend.Cache(), history, read, execute)
+	backendResponse, _ := result.(*vo.BackendResponse)
+	return backendResponse
+}
+
+func (e endpointUseCase) executeBackendByKind(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	history *aggregate.History,
+) *vo.BackendResponse {
 	switch backend.Kind() {
 	case enum.BackendKindHTTP:
-		backendResponse = e.executeHTTPBackend(ctx, executeData, backend, startTime, history)
-		return
+		return e.executeHTTPBackend(ctx, executeData, backend, startTime, history)
 	case enum.BackendKindPublisher:
-		backendResponse = e.executePublisherBackend(ctx, executeData, backend, startTime, history)
-		return
+		return e.executePublisherBackend(ctx, executeData, backend, startTime, history)
+	case enum.BackendKindGRPC:
+		return e.executeGRPCBackend(ctx, executeData, backend, startTime, history)
+	case enum.BackendKindGraphQL:
+		return e.executeGraphQLBackend(ctx, executeData, backend, startTime, history)
 	default:
 		panic(fmt.Sprintf("unknown backend kind: %v", backend.Kind()))
 	}
@@ -315,9 +666,8 @@ func (e endpointUseCase) readBackendResponseOnCacheIfNeeded(
 	ctx context.Context,
 	executeData dto.ExecuteEndpoint,
 	backend *vo.BackendConfig,
-	startTime time.Time,
 	history *aggregate.History,
-) *vo.BackendResponse {
+) *vo.BackendCacheEntry {
 	if !backend.HasCache() || !backend.AllowCache() {
 		return nil
 	}
@@ -327,9 +677,11 @@ func (e endpointUseCase) readBackendResponseOnCacheIfNeeded(
 	if checker.NonNil(err) {
 		e.backendLog.PrintWarnf(executeData, backend, "error to read backend response cache: %v", err)
 		return nil
+	} else if backendCacheEntry.IsZero() {
+		return nil
 	}
 
-	return backendCacheEntry.Response(time.Since(startTime))
+	return &backendCacheEntry
 }
 
 func (e endpointUseCase) executeHTTPBackend(
@@ -364,6 +716,36 @@ func (e endpointUseCase) executePublisherBackend(
 	}
 }
 
+func (e endpointUseCase) executeGRPCBackend(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	history *aggregate.History,
+) *vo.BackendResponse {
+	grpcBackendRequest, err := e.buildGRPCRequest(ctx, executeData, backend, history)
+	if checker.NonNil(err) {
+		return e.backendResponseFactory.BuildResponseByError(executeData.Endpoint, backend, err, time.Since(startTime))
+	} else {
+		return e.makeBackendGRPCRequest(ctx, executeData, backend, startTime, grpcBackendRequest)
+	}
+}
+
+func (e endpointUseCase) executeGraphQLBackend(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	history *aggregate.History,
+) *vo.BackendResponse {
+	graphQLBackendRequest, err := e.buildGraphQLRequest(ctx, executeData, backend, history)
+	if checker.NonNil(err) {
+		return e.backendResponseFactory.BuildResponseByError(executeData.Endpoint, backend, err, time.Since(startTime))
+	} else {
+		return e.makeBackendGraphQLRequest(ctx, executeData, backend, startTime, graphQLBackendRequest)
+	}
+}
+
 func (e endpointUseCase) makeConcurrentBackendHTTPRequest(
 	ctx context.Context,
 	executeData dto.ExecuteEndpoint,
@@ -394,7 +776,7 @@ func (e endpointUseCase) makeBackendHTTPRequest(
 ) *vo.BackendResponse {
 	e.backendLog.PrintHTTPRequest(executeData, backend, request)
 
-	httpResponse, err := e.httpClient.MakeRequest(ctx, executeData.Endpoint, executeData.Request, request)
+	httpResponse, err := e.makeHTTPRequestWithCredential(ctx, executeData, backend, request)
 
 	var backendResponse *vo.BackendResponse
 	if err = e.treatHTTPClientErr(err); checker.NonNil(err) {
@@ -408,6 +790,41 @@ func (e endpointUseCase) makeBackendHTTPRequest(
 	return backendResponse
 }
 
+// makeHTTPRequestWithCredential sends the bearer token of the credential referenced by the backend, when the backend
+// rejects it with 401 the token is discarded and the request is retried once with a new one.
+func (e endpointUseCase) makeHTTPRequestWithCredential(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	request *vo.HTTPBackendRequest,
+) (*http.Response, error) {
+	if !backend.HTTP().HasCredential() {
+		return e.httpClient.MakeRequest(ctx, executeData.Endpoint, executeData.Request, request)
+	}
+
+	credential := backend.HTTP().Credential()
+	token, err := e.credentialProvider.Token(ctx, credential, executeData.Request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	httpResponse, err := e.httpClient.MakeRequest(ctx, executeData.Endpoint, executeData.Request,
+		request.WithBearer(token))
+	if checker.NonNil(err) || checker.NotEquals(httpResponse.StatusCode, http.StatusUnauthorized) {
+		return httpResponse, err
+	}
+	_, _ = io.Copy(io.Discard, httpResponse.Body)
+	httpResponse.Body.Close()
+
+	e.credentialProvider.Invalidate(ctx, credential, token)
+
+	token, err = e.credentialProvider.Token(ctx, credential, executeData.Request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+	return e.httpClient.MakeRequest(ctx, executeData.Endpoint, executeData.Request, request.WithBearer(token))
+}
+
 func (e endpointUseCase) makeBackendPublisherRequest(
 	ctx context.Context,
 	executeData dto.ExecuteEndpoint,
@@ -431,6 +848,52 @@ func (e endpointUseCase) makeBackendPublisherRequest(
 	return backendResponse
 }
 
+func (e endpointUseCase) makeBackendGRPCRequest(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	grpcBackendRequest *vo.GRPCBackendRequest,
+) *vo.BackendResponse {
+	e.backendLog.PrintGRPCRequest(executeData, backend, grpcBackendRequest)
+
+	grpcResponse, err := e.grpcClient.Invoke(ctx, executeData.Endpoint, executeData.Request, grpcBackendRequest)
+
+	var backendResponse *vo.BackendResponse
+	if err = e.treatGRPCClientErr(err); checker.NonNil(err) {
+		backendResponse = e.backendResponseFactory.BuildResponseByError(executeData.Endpoint, backend, err, time.Since(startTime))
+	} else {
+		backendResponse = e.backendResponseFactory.BuildResponseByGRPC(grpcResponse, time.Since(startTime))
+	}
+
+	e.backendLog.PrintResponse(executeData, backend, backendResponse)
+
+	return backendResponse
+}
+
+func (e endpointUseCase) makeBackendGraphQLRequest(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	startTime time.Time,
+	request *vo.HTTPBackendRequest,
+) *vo.BackendResponse {
+	e.backendLog.PrintHTTPRequest(executeData, backend, request)
+
+	httpResponse, err := e.httpClient.MakeRequest(ctx, executeData.Endpoint, executeData.Request, request)
+
+	var backendResponse *vo.BackendResponse
+	if err = e.treatHTTPClientErr(err); checker.NonNil(err) {
+		backendResponse = e.backendResponseFactory.BuildResponseByError(executeData.Endpoint, backend, err, time.Since(startTime))
+	} else {
+		backendResponse = e.backendResponseFactory.BuildResponseByGraphQL(httpResponse, time.Since(startTime))
+	}
+
+	e.backendLog.PrintResponse(executeData, backend, backendResponse)
+
+	return backendResponse
+}
+
 func (e endpointUseCase) treatHTTPClientErr(err error) error {
 	if checker.IsNil(err) {
 		return nil
@@ -467,6 +930,22 @@ func (e endpointUseCase) treatPublisherClientErr(err error) error {
 	return app.NewErrBackendBadGateway(err)
 }
 
+// treatGRPCClientErr only receives the failures that happened before the call reached the backend, the statuses
+// returned by the backend come as a response.
+func (e endpointUseCase) treatGRPCClientErr(err error) error {
+	if checker.IsNil(err) {
+		return nil
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return app.NewErrBackendConcurrentCancelled()
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		return app.NewErrBackendGatewayTimeout(err)
+	}
+
+	return app.NewErrBackendBadGateway(err)
+}
+
 func (e endpointUseCase) shouldBackendAbort(backend *vo.BackendConfig, response *vo.BackendResponse) bool {
 	if backend.Execution().IsBestEffort() {
 		return false
@@ -548,6 +1027,78 @@ func (e endpointUseCase) buildPublisherRequest(
 	return nil, err
 }
 
+func (e endpointUseCase) buildGRPCRequest(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+) (*vo.GRPCBackendRequest, error) {
+	spanName := fmt.Sprintf("backend/%s build-request", backend.ID())
+	ctx, span := telemetry.Tracer().Start(ctx, spanName,
+		trace.WithAttributes(
+			attribute.Int("grpc.transformations", backend.GRPC().CountAllDataTransforms()),
+			attribute.String("grpc.method", backend.GRPC().FullMethod()),
+		),
+	)
+	defer span.End()
+
+	grpcRequest, errs := e.backendRequestFactory.BuildGRPCRequest(backend, executeData.Request, history)
+	if checker.IsEmpty(errs) {
+		return grpcRequest, nil
+	} else if backend.Execution().ContinueOn(enum.ExecutionOnBuild) {
+		for _, err := range errs {
+			e.backendLog.PrintWarnf(executeData, backend, "error build GRPC backend request: %v", err)
+		}
+		return grpcRequest, nil
+	}
+
+	err := errors.NewByChainf(
+		errs,
+		"failed to build GRPC backend request (id=%s method=%s)",
+		backend.ID(),
+		backend.GRPC().FullMethod(),
+	)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return nil, err
+}
+
+func (e endpointUseCase) buildGraphQLRequest(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+) (*vo.HTTPBackendRequest, error) {
+	spanName := fmt.Sprintf("backend/%s build-request", backend.ID())
+	ctx, span := telemetry.Tracer().Start(ctx, spanName,
+		trace.WithAttributes(
+			attribute.Int("graphql.transformations", backend.GraphQL().CountAllDataTransforms()),
+			attribute.String("graphql.operation", backend.GraphQL().OperationName()),
+		),
+	)
+	defer span.End()
+
+	graphQLRequest, errs := e.backendRequestFactory.BuildGraphQLRequest(backend, executeData.Request, history)
+	if checker.IsEmpty(errs) {
+		return graphQLRequest, nil
+	} else if backend.Execution().ContinueOn(enum.ExecutionOnBuild) {
+		for _, err := range errs {
+			e.backendLog.PrintWarnf(executeData, backend, "error build GRAPHQL backend request: %v", err)
+		}
+		return graphQLRequest, nil
+	}
+
+	err := errors.NewByChainf(
+		errs,
+		"failed to build GRAPHQL backend request (id=%s path=%s)",
+		backend.ID(),
+		backend.GraphQL().Path(),
+	)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return nil, err
+}
+
 func (e endpointUseCase) buildEndpointResponse(
 	ctx context.Context,
 	executeData dto.ExecuteEndpoint,
@@ -655,32 +1206,112 @@ func (e endpointUseCase) writeBackendResponseOnCacheIfNeeded(
 	history *aggregate.History,
 	backendResponse *vo.BackendResponse,
 ) {
-	if !backend.HasCache() || !backend.AllowCache() || checker.IsNil(backendResponse) || backendResponse.ComesFromCache() {
+	config, ok := e.buildBackendCacheConfigToWrite(executeData, backend, history, backendResponse)
+	if !ok {
 		return
 	}
 
-	entry := vo.NewBackendCacheEntry(backend.Cache(), backendResponse)
+	entry := vo.NewBackendCacheEntry(config, backendResponse)
 
-	err := e.cacheService.Write(ctx, backend.Cache(), entry, executeData.Request, history)
+	err := e.cacheService.Write(ctx, config, entry, executeData.Request, history)
 	if checker.NonNil(err) {
 		e.backendLog.PrintWarnf(executeData, backend, "error to write backend response cache: %v", err)
 	}
 }
 
+// buildBackendCacheConfigToWrite returns the backend cache config adjusted by the response directives, and false when
+// the response would not be stored.
+func (e endpointUseCase) buildBackendCacheConfigToWrite(
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+	backendResponse *vo.BackendResponse,
+) (*vo.CacheConfig, bool) {
+	if !backend.HasCache() || !backend.AllowCache() || checker.IsNil(backendResponse) || backendResponse.ComesFromCache() {
+		return nil, false
+	}
+
+	shouldWrite, err := e.cacheService.ShouldWrite(backend.Cache(), executeData.Request, history)
+	if checker.NonNil(err) {
+		e.backendLog.PrintWarnf(executeData, backend, "error to write backend response cache: %v", err)
+		return nil, false
+	} else if !shouldWrite {
+		return nil, false
+	}
+
+	return e.cacheService.ApplyDirectives(backend.Cache(), backendResponse.Metadata())
+}
+
 func (e endpointUseCase) writeEndpointResponseOnCacheIfNeeded(
 	ctx context.Context,
 	executeData dto.ExecuteEndpoint,
 	history *aggregate.History,
 	response *vo.EndpointResponse,
-) {
+) bool {
+	config, ok := e.buildEndpointCacheConfigToWrite(executeData, history)
+	if !ok {
+		return false
+	}
+
+	entry := vo.NewEndpointCacheEntry(config, response)
+
+	err := e.cacheService.Write(ctx, config, entry, executeData.Request, history)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to write endpoint response cache: %v", err)
+		return false
+	}
+	return true
+}
+
+// buildEndpointCacheConfigToWrite returns the endpoint cache config adjusted by the backends responses directives, and
+// false when the response would not be stored.
+func (e endpointUseCase) buildEndpointCacheConfigToWrite(executeData dto.ExecuteEndpoint, history *aggregate.History,
+) (*vo.CacheConfig, bool) {
 	if !executeData.Endpoint.HasCache() || !executeData.Endpoint.AllowCache() {
+		return nil, false
+	}
+
+	shouldWrite, err := e.cacheService.ShouldWrite(executeData.Endpoint.Cache(), executeData.Request, history)
+	if checker.NonNil(err) {
+		e.endpointLog.PrintWarnf(executeData, "error to write endpoint response cache: %v", err)
+		return nil, false
+	} else if !shouldWrite {
+		return nil, false
+	}
+
+	return e.cacheService.ApplyDirectives(executeData.Endpoint.Cache(), history.FinalResponseMetadata()...)
+}
+
+func (e endpointUseCase) invalidateBackendCacheIfNeeded(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+	backendResponse *vo.BackendResponse,
+) {
+	if !backend.HasInvalidate() || checker.IsNil(backendResponse) || backendResponse.ComesFromCache() ||
+		!backendResponse.OK() {
 		return
 	}
 
-	entry := vo.NewEndpointCacheEntry(executeData.Endpoint.Cache(), response)
+	err := e.cacheService.Invalidate(ctx, backend.Invalidate(), executeData.Request, history)
+	if checker.NonNil(err) {
+		e.backendLog.PrintWarnf(executeData, backend, "error to invalidate cache: %v", err)
+	}
+}
+
+func (e endpointUseCase) invalidateEndpointCacheIfNeeded(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	history *aggregate.History,
+	response *vo.EndpointResponse,
+) {
+	if !executeData.Endpoint.HasInvalidate() || !response.Status().OK() {
+		return
+	}
 
-	err := e.cacheService.Write(ctx, executeData.Endpoint.Cache(), entry, executeData.Request, history)
+	err := e.cacheService.Invalidate(ctx, executeData.Endpoint.Invalidate(), executeData.Request, history)
 	if checker.NonNil(err) {
-		e.endpointLog.PrintWarnf(executeData, "error to write endpoint response cache: %v", err)
+		e.endpointLog.PrintWarnf(executeData, "error to invalidate cache: %v", err)
 	}
 }
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package usecase
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tech4works/gopen-gateway/internal/app/factory"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/domain/service"
+)
+
+func newAbortTestBackend(mode enum.ExecutionMode) *vo.BackendConfig {
+	backend := vo.NewBackendConfig(enum.BackendFlowNormal, nil, nil, "graphql", vo.NewBackendExecutionConfig(0, false,
+		mode, nil), nil, enum.BackendKindGraphQL, nil, nil, nil, nil, nil, nil, nil)
+	return &backend
+}
+
+func newAbortTestResponse(body string) *vo.BackendResponse {
+	return factory.NewBackendResponse(service.BuildPipeline{}).BuildResponseByGraphQL(&http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, 0)
+}
+
+func TestEndpointUseCaseShouldBackendAbort(t *testing.T) {
+	serverError := `{"data":null,"errors":[{"message":"boom","extensions":{"code":"INTERNAL_SERVER_ERROR"}}]}`
+	clientError := `{"data":null,"errors":[{"message":"invalid id","extensions":{"code":"BAD_USER_INPUT"}}]}`
+	ok := `{"data":{"user":{"id":1}}}`
+
+	tests := []struct {
+		name string
+		mode enum.ExecutionMode
+		body string
+		want bool
+	}{
+		{"fail fast on server error", enum.ExecutionModeFailFast, serverError, true},
+		{"fail fast on client error", enum.ExecutionModeFailFast, clientError, false},
+		{"fail fast on data", enum.ExecutionModeFailFast, ok, false},
+		{"best effort on server error", enum.ExecutionModeBestEffort, serverError, false},
+		{"best effort on client error", enum.ExecutionModeBestEffort, clientError, false},
+	}
+
+	e := endpointUseCase{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.shouldBackendAbort(newAbortTestBackend(tt.mode), newAbortTestResponse(tt.body)); got != tt.want {
+				t.Errorf("shouldBackendAbort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
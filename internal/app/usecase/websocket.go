@@ -0,0 +1,193 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/app/factory"
+	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/aggregate"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+type webSocketUseCase struct {
+	backendRequestFactory   factory.BackendRequest
+	backendResponseFactory  factory.BackendResponse
+	endpointResponseFactory factory.EndpointResponse
+	webSocketClient         app.WebSocketClient
+	endpointLog             app.EndpointLog
+	backendLog              app.BackendLog
+}
+
+// WebSocketUpgrade switches the client connection to the WebSocket protocol, answering with the subprotocol
+// negotiated with the backend.
+type WebSocketUpgrade func(subprotocol string) (app.WebSocketConn, error)
+
+type WebSocket interface {
+	Execute(ctx context.Context, executeData dto.ExecuteEndpoint, upgrade WebSocketUpgrade) *vo.EndpointResponse
+}
+
+func NewWebSocket(
+	backendRequestFactory factory.BackendRequest,
+	backendResponseFactory factory.BackendResponse,
+	endpointResponseFactory factory.EndpointResponse,
+	webSocketClient app.WebSocketClient,
+	endpointLog app.EndpointLog,
+	backendLog app.BackendLog,
+) WebSocket {
+	return webSocketUseCase{
+		backendRequestFactory:   backendRequestFactory,
+		backendResponseFactory:  backendResponseFactory,
+		endpointResponseFactory: endpointResponseFactory,
+		webSocketClient:         webSocketClient,
+		endpointLog:             endpointLog,
+		backendLog:              backendLog,
+	}
+}
+
+// Execute dials the backend before upgrading the client, so a backend failure is still answered as a regular
+// HTTP error. Once both connections are open, frames are pumped in both directions until one side closes.
+func (w webSocketUseCase) Execute(ctx context.Context, executeData dto.ExecuteEndpoint, upgrade WebSocketUpgrade,
+) *vo.EndpointResponse {
+	backend := w.findBackend(executeData.Endpoint)
+	if checker.IsNil(backend) {
+		return w.endpointResponseFactory.BuildErrorResponse(executeData.Endpoint,
+			errors.Newf("websocket failed: endpoint=%s reason=no HTTP backend configured", executeData.Endpoint.Path()))
+	}
+
+	history := aggregate.NewHistoryWithSize(0)
+
+	backendRequest, errs := w.backendRequestFactory.BuildHTTPRequest(backend, executeData.Request, history)
+	if checker.IsNotEmpty(errs) && !backend.Execution().ContinueOn(enum.ExecutionOnBuild) {
+		return w.endpointResponseFactory.BuildErrorResponse(executeData.Endpoint, errors.NewByChainf(errs,
+			"failed to build websocket backend request (id=%s path=%s)", backend.ID(), backend.HTTP().Path()))
+	}
+	for _, err := range errs {
+		w.backendLog.PrintWarnf(executeData, backend, "error build WEBSOCKET backend request: %v", err)
+	}
+
+	w.backendLog.PrintHTTPRequest(executeData, backend, backendRequest)
+
+	backendConn, err := w.webSocketClient.Dial(ctx, executeData.Endpoint, executeData.Request, backendRequest)
+	if checker.NonNil(err) {
+		w.backendLog.PrintErrorf(executeData, backend, "error dial websocket backend: %v", err)
+		return vo.NewEndpointResponseWithOnlyStatus(vo.NewResponseStatus(enum.ResponseStatusBadGateway, nil,
+			app.NewErrBackendBadGateway(err).Error()))
+	}
+	defer backendConn.Close()
+
+	clientConn, err := upgrade(backendConn.Subprotocol())
+	if checker.NonNil(err) {
+		w.endpointLog.PrintWarnf(executeData, "error upgrade websocket client: %v", err)
+		return vo.NewEndpointResponseWithOnlyStatus(vo.NewResponseStatus(enum.ResponseStatusInvalidArgument, nil,
+			err.Error()))
+	}
+	defer clientConn.Close()
+
+	w.endpointLog.PrintInfo(executeData, "websocket session opened")
+
+	w.pump(ctx, executeData, backend, history, clientConn, backendConn)
+
+	w.endpointLog.PrintInfo(executeData, "websocket session closed")
+
+	return vo.NewEndpointResponseWithOnlyStatus(vo.NewResponseStatusByValue(enum.ResponseStatusOK))
+}
+
+// findBackend returns the first normal HTTP backend, the one that receives the proxied connection.
+func (w webSocketUseCase) findBackend(endpoint *vo.EndpointConfig) *vo.BackendConfig {
+	for i := range endpoint.Backends() {
+		backend := &endpoint.Backends()[i]
+		if backend.IsNormal() && backend.IsHTTP() {
+			return backend
+		}
+	}
+	return nil
+}
+
+func (w webSocketUseCase) pump(
+	ctx context.Context,
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	history *aggregate.History,
+	clientConn,
+	backendConn app.WebSocketConn,
+) {
+	done := make(chan struct{})
+	var once sync.Once
+	finish := func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+
+	go func() {
+		defer finish()
+		w.forward(clientConn, backendConn, func(message *vo.WebSocketMessage) (*vo.WebSocketMessage, []error) {
+			return w.backendRequestFactory.BuildWebSocketMessage(backend, message, executeData.Request, history)
+		}, func(err error) {
+			w.backendLog.PrintWarnf(executeData, backend, "error build websocket client message: %v", err)
+		})
+	}()
+	go func() {
+		defer finish()
+		w.forward(backendConn, clientConn, func(message *vo.WebSocketMessage) (*vo.WebSocketMessage, []error) {
+			return w.backendResponseFactory.BuildWebSocketMessage(backend, message, executeData.Request, history)
+		}, func(err error) {
+			w.backendLog.PrintWarnf(executeData, backend, "error build websocket backend message: %v", err)
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// forward reads every frame of the source, transforms and writes it on the destination. Frames left without
+// payload by the transformations (e.g. omit) are dropped.
+func (w webSocketUseCase) forward(
+	src,
+	dst app.WebSocketConn,
+	build func(message *vo.WebSocketMessage) (*vo.WebSocketMessage, []error),
+	warn func(err error),
+) {
+	for {
+		message, err := src.ReadMessage()
+		if checker.NonNil(err) {
+			return
+		}
+
+		message, errs := build(message)
+		for _, err = range errs {
+			warn(err)
+		}
+		if !message.HasPayload() {
+			continue
+		}
+
+		if err = dst.WriteMessage(message); checker.NonNil(err) {
+			return
+		}
+	}
+}
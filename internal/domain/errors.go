@@ -33,7 +33,10 @@ const (
 	codeErrLimiterMetadataTooLarge         = "METADATA_TOO_LARGE"
 	codeErrLimiterPayloadTooLarge          = "PAYLOAD_TOO_LARGE"
 	codeErrLimiterTooManyRequests          = "TOO_MANY_REQUESTS"
+	codeErrLimiterConcurrencyExceeded      = "CONCURRENCY_EXCEEDED"
+	codeErrLimiterLoadShed                 = "LOAD_SHED"
 	codeErrCacheNotFound                   = "CACHE_NOT_FOUND"
+	codeErrCachePurgeInvalid               = "CACHE_PURGE_INVALID"
 	codeErrEvalGuards                      = "EVAL_GUARDS"
 	codeErrJSONPathNotModified             = "JSON_PATH_NOT_MODIFIED"
 )
@@ -44,8 +47,11 @@ const (
 	msgErrModifierIncompatibleContentType = "modifier failed: op=%s incompatible payload content-type=%s to modify"
 	msgErrLimiterMetadataTooLarge         = "limiter failed: header too large error permitted=%s"
 	msgErrLimiterPayloadTooLarge          = "limiter failed: payload too large error permitted=%s"
-	msgErrLimiterTooManyRequests          = "limiter failed: too many requests error permitted=%s every=%s"
+	msgErrLimiterTooManyRequests          = "limiter failed: too many requests error permitted=%d every=%s"
+	msgErrLimiterConcurrencyExceeded      = "limiter failed: concurrency exceeded error permitted=%d queue=%d reason=%s"
+	msgErrLimiterLoadShed                 = "limiter failed: load shed error reason=%s"
 	msgErrCacheNotFound                   = "cache failed: not found by key=%s"
+	msgErrCachePurgeInvalid               = "cache failed: op=purge key=%s reason=%s"
 	msgErrEvalGuards                      = "eval guards: op=eval-guards reason=%s should-run=false"
 	msgErrJSONPathNotModified             = "jsonpath failed: op=%s not modified %s"
 )
@@ -55,9 +61,12 @@ var (
 	ErrModifierActionNotImplemented    = errors.TargetWithCode(codeErrModifierActionNotImplemented)
 	ErrModifierIncompatibleContentType = errors.TargetWithCode(codeErrModifierIncompatibleContentType)
 	ErrCacheNotFound                   = errors.TargetWithCode(codeErrCacheNotFound)
+	ErrCachePurgeInvalid               = errors.TargetWithCode(codeErrCachePurgeInvalid)
 	ErrLimiterMetadataTooLarge         = errors.TargetWithCode(codeErrLimiterMetadataTooLarge)
 	ErrLimiterPayloadTooLarge          = errors.TargetWithCode(codeErrLimiterPayloadTooLarge)
 	ErrLimiterTooManyRequests          = errors.TargetWithCode(codeErrLimiterTooManyRequests)
+	ErrLimiterConcurrencyExceeded      = errors.TargetWithCode(codeErrLimiterConcurrencyExceeded)
+	ErrLimiterLoadShed                 = errors.TargetWithCode(codeErrLimiterLoadShed)
 	ErrEvalGuards                      = errors.TargetWithCode(codeErrEvalGuards)
 	ErrJSONNotModified                 = errors.TargetWithCode(codeErrJSONPathNotModified)
 )
@@ -100,7 +109,7 @@ func NewErrLimiterMetadataTooLarge(limit string) error {
 }
 
 func NewErrLimiterTooManyRequests(capacity int, every time.Duration) error {
-	return errors.NewWithSkipCaller(
+	return errors.NewWithSkipCallerAndCodef(
 		2,
 		codeErrLimiterTooManyRequests,
 		msgErrLimiterTooManyRequests,
@@ -109,10 +118,29 @@ func NewErrLimiterTooManyRequests(capacity int, every time.Duration) error {
 	)
 }
 
+func NewErrLimiterConcurrencyExceeded(maxInFlight, maxQueue int, reason string) error {
+	return errors.NewWithSkipCallerAndCodef(
+		2,
+		codeErrLimiterConcurrencyExceeded,
+		msgErrLimiterConcurrencyExceeded,
+		maxInFlight,
+		maxQueue,
+		reason,
+	)
+}
+
+func NewErrLimiterLoadShed(reason string) error {
+	return errors.NewWithSkipCallerAndCodef(2, codeErrLimiterLoadShed, msgErrLimiterLoadShed, reason)
+}
+
 func NewErrCacheNotFound(key string) error {
 	return errors.NewWithSkipCallerAndCodef(2, codeErrCacheNotFound, msgErrCacheNotFound, key)
 }
 
+func NewErrCachePurgeInvalid(key, reason string) error {
+	return errors.NewWithSkipCallerAndCodef(2, codeErrCachePurgeInvalid, msgErrCachePurgeInvalid, key, reason)
+}
+
 func NewErrEvalGuards(reason string) error {
 	return errors.NewWithSkipCallerAndCodef(2, codeErrEvalGuards, msgErrEvalGuards, reason)
 }
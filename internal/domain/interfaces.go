@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
 )
 
 type Converter interface {
@@ -60,7 +61,17 @@ type Nomenclature interface {
 
 type Store interface {
 	Set(ctx context.Context, key, value string, ttl time.Duration) error
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
 	Del(ctx context.Context, key string) error
+	DelIfEquals(ctx context.Context, key, value string) error
+	DelByPattern(ctx context.Context, pattern string) error
+	Tag(ctx context.Context, key string, tags []string, ttl time.Duration) error
+	DelByTag(ctx context.Context, tag string) error
 	Get(ctx context.Context, key string) (string, error)
 	Close() error
 }
+
+type RateStore interface {
+	Allow(ctx context.Context, algorithm enum.LimiterAlgorithm, key string, capacity int, every time.Duration) (
+		*vo.LimiterRateStatus, error)
+}
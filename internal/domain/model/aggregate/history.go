@@ -119,6 +119,24 @@ func (h *History) IsSingleFinalResponse() bool {
 	return checker.Equals(h.sizeForFinalResponseUnlocked(), 1)
 }
 
+// SingleFinalResponse returns the backend response composing the final response alone, nil when there are none or
+// many.
+func (h *History) SingleFinalResponse() *vo.BackendResponse {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var single *vo.BackendResponse
+	for i := 0; checker.IsLessThan(i, h.sizeUnlocked()); i++ {
+		if !h.shouldBeInFinalResponseUnlocked(i) {
+			continue
+		} else if checker.NonNil(single) {
+			return nil
+		}
+		single = h.responses[i]
+	}
+	return single
+}
+
 func (h *History) IsMultipleFinalResponse() bool {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -320,3 +338,18 @@ func (h *History) NewestFinalResponseBackendCacheTTLMillis() int64 {
 	}
 	return newestMillis
 }
+
+// FinalResponseMetadata returns the metadata of the backends responses composing the final response, used to honor
+// their HTTP caching directives when caching the endpoint response.
+func (h *History) FinalResponseMetadata() []vo.Metadata {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var metadata []vo.Metadata
+	for i := 0; checker.IsLessThan(i, h.sizeUnlocked()); i++ {
+		if h.shouldBeInFinalResponseUnlocked(i) {
+			metadata = append(metadata, h.responses[i].Metadata())
+		}
+	}
+	return metadata
+}
@@ -60,10 +60,25 @@ type DegradationKind string
 
 type CacheKind string
 
+type WebSocketMessageType string
+
+type HMACAlgorithm string
+
+type SignatureEncoding string
+
+type TLSClientAuth string
+
+type LimiterAlgorithm string
+
+type LimiterOnFailure string
+
+type CacheHTTPTTL string
+
 const (
 	ProtocolHTTP      Protocol = "HTTP"
 	ProtocolGRPC      Protocol = "GRPC"
 	ProtocolWebSocket Protocol = "WEBSOCKET"
+	ProtocolConsumer  Protocol = "CONSUMER"
 )
 const (
 	BackendOutcomeExecuted  BackendOutcome = "EXECUTED"
@@ -95,6 +110,8 @@ const (
 const (
 	BackendKindHTTP      BackendKind = "HTTP"
 	BackendKindPublisher BackendKind = "PUBLISHER"
+	BackendKindGRPC      BackendKind = "GRPC"
+	BackendKindGraphQL   BackendKind = "GRAPHQL"
 )
 const (
 	NomenclatureCamel          Nomenclature = "CAMEL"
@@ -105,8 +122,12 @@ const (
 	NomenclatureScreamingKebab Nomenclature = "SCREAMING_KEBAB"
 )
 const (
-	BackendBrokerAwsSqs BackendBroker = "AWS/SQS"
-	BackendBrokerAwsSns BackendBroker = "AWS/SNS"
+	BackendBrokerAwsSqs      BackendBroker = "AWS/SQS"
+	BackendBrokerAwsSns      BackendBroker = "AWS/SNS"
+	BackendBrokerKafka       BackendBroker = "KAFKA"
+	BackendBrokerAMQP        BackendBroker = "AMQP"
+	BackendBrokerNATS        BackendBroker = "NATS"
+	BackendBrokerRedisStream BackendBroker = "REDIS/STREAM"
 )
 const (
 	TemplateMergeBase TemplateMerge = "BASE"
@@ -175,11 +196,43 @@ const (
 	DegradationKindDeduplicationID DegradationKind = "DEDUPLICATION_ID"
 	DegradationKindGroupID         DegradationKind = "GROUP_ID"
 	DegradationKindAttributes      DegradationKind = "ATTRIBUTES"
+	DegradationKindRoutingKey      DegradationKind = "ROUTING_KEY"
 )
 const (
 	CacheKindEndpoint CacheKind = "ENDPOINT"
 	CacheKindBackend  CacheKind = "BACKEND"
 )
+const (
+	WebSocketMessageTypeText   WebSocketMessageType = "TEXT"
+	WebSocketMessageTypeBinary WebSocketMessageType = "BINARY"
+)
+const (
+	HMACAlgorithmSHA1   HMACAlgorithm = "SHA1"
+	HMACAlgorithmSHA256 HMACAlgorithm = "SHA256"
+	HMACAlgorithmSHA512 HMACAlgorithm = "SHA512"
+)
+const (
+	SignatureEncodingHex    SignatureEncoding = "HEX"
+	SignatureEncodingBase64 SignatureEncoding = "BASE64"
+)
+const (
+	TLSClientAuthNone          TLSClientAuth = "NONE"
+	TLSClientAuthVerifyIfGiven TLSClientAuth = "VERIFY_IF_GIVEN"
+	TLSClientAuthRequire       TLSClientAuth = "REQUIRE"
+)
+const (
+	LimiterAlgorithmTokenBucket   LimiterAlgorithm = "TOKEN_BUCKET"
+	LimiterAlgorithmSlidingWindow LimiterAlgorithm = "SLIDING_WINDOW"
+)
+const (
+	LimiterOnFailureLocal  LimiterOnFailure = "LOCAL"
+	LimiterOnFailureOpen   LimiterOnFailure = "OPEN"
+	LimiterOnFailureClosed LimiterOnFailure = "CLOSED"
+)
+const (
+	CacheHTTPTTLSet CacheHTTPTTL = "SET"
+	CacheHTTPTTLCap CacheHTTPTTL = "CAP"
+)
 
 func NewResponseStatusFromGRPC(code codes.Code) ResponseStatus {
 	switch code {
@@ -226,7 +279,8 @@ func (p BackendBroker) String() string {
 
 func (p BackendBroker) IsEnumValid() bool {
 	switch p {
-	case BackendBrokerAwsSqs, BackendBrokerAwsSns:
+	case BackendBrokerAwsSqs, BackendBrokerAwsSns, BackendBrokerKafka, BackendBrokerAMQP,
+		BackendBrokerNATS, BackendBrokerRedisStream:
 		return true
 	}
 	return false
@@ -284,7 +338,7 @@ func (m ModifierAction) IsEnumValid() bool {
 func (d DegradationKind) IsEnumValid() bool {
 	switch d {
 	case DegradationKindMetadata, DegradationKindQuery, DegradationKindURLPath, DegradationKindPayload,
-		DegradationKindDeduplicationID, DegradationKindGroupID, DegradationKindAttributes:
+		DegradationKindDeduplicationID, DegradationKindGroupID, DegradationKindAttributes, DegradationKindRoutingKey:
 		return true
 	}
 	return false
@@ -324,7 +378,7 @@ func (c ComponentMerge) IsEnumValid() bool {
 
 func (b BackendKind) IsEnumValid() bool {
 	switch b {
-	case BackendKindHTTP, BackendKindPublisher:
+	case BackendKindHTTP, BackendKindPublisher, BackendKindGRPC, BackendKindGraphQL:
 		return true
 	}
 	return false
@@ -361,7 +415,7 @@ func (p AttributeValueType) String() string {
 
 func (p Protocol) IsEnumValid() bool {
 	switch p {
-	case ProtocolHTTP, ProtocolGRPC, ProtocolWebSocket:
+	case ProtocolHTTP, ProtocolGRPC, ProtocolWebSocket, ProtocolConsumer:
 		return true
 	}
 	return false
@@ -374,3 +428,51 @@ func (c CacheKind) IsEnumValid() bool {
 	}
 	return false
 }
+
+func (h HMACAlgorithm) IsEnumValid() bool {
+	switch h {
+	case HMACAlgorithmSHA1, HMACAlgorithmSHA256, HMACAlgorithmSHA512:
+		return true
+	}
+	return false
+}
+
+func (s SignatureEncoding) IsEnumValid() bool {
+	switch s {
+	case SignatureEncodingHex, SignatureEncodingBase64:
+		return true
+	}
+	return false
+}
+
+func (t TLSClientAuth) IsEnumValid() bool {
+	switch t {
+	case TLSClientAuthNone, TLSClientAuthVerifyIfGiven, TLSClientAuthRequire:
+		return true
+	}
+	return false
+}
+
+func (l LimiterAlgorithm) IsEnumValid() bool {
+	switch l {
+	case LimiterAlgorithmTokenBucket, LimiterAlgorithmSlidingWindow:
+		return true
+	}
+	return false
+}
+
+func (l LimiterOnFailure) IsEnumValid() bool {
+	switch l {
+	case LimiterOnFailureLocal, LimiterOnFailureOpen, LimiterOnFailureClosed:
+		return true
+	}
+	return false
+}
+
+func (c CacheHTTPTTL) IsEnumValid() bool {
+	switch c {
+	case CacheHTTPTTLSet, CacheHTTPTTLCap:
+		return true
+	}
+	return false
+}
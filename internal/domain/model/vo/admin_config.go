@@ -0,0 +1,44 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import "github.com/tech4works/checker"
+
+type AdminConfig struct {
+	path string
+	auth *AuthConfig
+}
+
+func NewAdminConfig(path string, auth *AuthConfig) *AdminConfig {
+	return &AdminConfig{
+		path: path,
+		auth: auth,
+	}
+}
+
+// Path returns the prefix of the administrative routes.
+// Default: /admin.
+func (a *AdminConfig) Path() string {
+	if checker.IsNotEmpty(a.path) {
+		return a.path
+	}
+	return "/admin"
+}
+
+func (a *AdminConfig) Auth() *AuthConfig {
+	return a.auth
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+// APIConsumer is the identity resolved from an API key, exposed to the dynamic values as #request.consumer.
+type APIConsumer struct {
+	name     string
+	metadata map[string]any
+}
+
+func NewAPIConsumer(name string, metadata map[string]any) *APIConsumer {
+	return &APIConsumer{
+		name:     name,
+		metadata: metadata,
+	}
+}
+
+func (a APIConsumer) Name() string {
+	return a.name
+}
+
+func (a APIConsumer) Metadata() map[string]any {
+	return a.metadata
+}
+
+func (a APIConsumer) Map() map[string]any {
+	return map[string]any{
+		"name":     a.name,
+		"metadata": a.metadata,
+	}
+}
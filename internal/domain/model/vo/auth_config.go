@@ -0,0 +1,352 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"time"
+
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+type AuthConfig struct {
+	jwt           *AuthJWTConfig
+	introspection *AuthIntrospectionConfig
+	apiKey        *AuthAPIKeyConfig
+	signature     *AuthSignatureConfig
+	scopes        []string
+}
+
+type AuthJWTConfig struct {
+	jwksURL     string
+	jwksFile    string
+	jwksRefresh Duration
+	issuer      string
+	audience    []string
+	algorithms  []string
+	clockSkew   Duration
+}
+
+type AuthIntrospectionConfig struct {
+	url           string
+	clientID      string
+	clientSecret  string
+	tokenTypeHint string
+	cacheTTL      Duration
+}
+
+type AuthAPIKeyConfig struct {
+	header string
+	query  string
+	keys   map[string]*APIConsumer
+	file   string
+	store  bool
+}
+
+type AuthSignatureConfig struct {
+	header          string
+	prefix          string
+	algorithm       enum.HMACAlgorithm
+	encoding        enum.SignatureEncoding
+	secretEnv       string
+	canonical       string
+	timestampHeader string
+	tolerance       Duration
+}
+
+func NewAuthConfig(jwt *AuthJWTConfig, introspection *AuthIntrospectionConfig, apiKey *AuthAPIKeyConfig,
+	signature *AuthSignatureConfig, scopes []string) *AuthConfig {
+	return &AuthConfig{
+		jwt:           jwt,
+		introspection: introspection,
+		apiKey:        apiKey,
+		signature:     signature,
+		scopes:        scopes,
+	}
+}
+
+func NewAuthJWTConfig(jwksURL, jwksFile string, jwksRefresh Duration, issuer string, audience, algorithms []string,
+	clockSkew Duration) *AuthJWTConfig {
+	return &AuthJWTConfig{
+		jwksURL:     jwksURL,
+		jwksFile:    jwksFile,
+		jwksRefresh: jwksRefresh,
+		issuer:      issuer,
+		audience:    audience,
+		algorithms:  algorithms,
+		clockSkew:   clockSkew,
+	}
+}
+
+func (a AuthConfig) HasJWT() bool {
+	return checker.NonNil(a.jwt)
+}
+
+func (a AuthConfig) JWT() *AuthJWTConfig {
+	return a.jwt
+}
+
+func NewAuthIntrospectionConfig(url, clientID, clientSecret, tokenTypeHint string, cacheTTL Duration,
+) *AuthIntrospectionConfig {
+	return &AuthIntrospectionConfig{
+		url:           url,
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		tokenTypeHint: tokenTypeHint,
+		cacheTTL:      cacheTTL,
+	}
+}
+
+func (a AuthConfig) HasIntrospection() bool {
+	return checker.NonNil(a.introspection)
+}
+
+func (a AuthConfig) Introspection() *AuthIntrospectionConfig {
+	return a.introspection
+}
+
+func NewAuthAPIKeyConfig(header, query string, keys map[string]*APIConsumer, file string, store bool,
+) *AuthAPIKeyConfig {
+	return &AuthAPIKeyConfig{
+		header: header,
+		query:  query,
+		keys:   keys,
+		file:   file,
+		store:  store,
+	}
+}
+
+func NewAuthSignatureConfig(header, prefix string, algorithm enum.HMACAlgorithm, encoding enum.SignatureEncoding,
+	secretEnv, canonical, timestampHeader string, tolerance Duration) *AuthSignatureConfig {
+	return &AuthSignatureConfig{
+		header:          header,
+		prefix:          prefix,
+		algorithm:       algorithm,
+		encoding:        encoding,
+		secretEnv:       secretEnv,
+		canonical:       canonical,
+		timestampHeader: timestampHeader,
+		tolerance:       tolerance,
+	}
+}
+
+func (a AuthConfig) HasSignature() bool {
+	return checker.NonNil(a.signature)
+}
+
+func (a AuthConfig) Signature() *AuthSignatureConfig {
+	return a.signature
+}
+
+func (a AuthConfig) HasAPIKey() bool {
+	return checker.NonNil(a.apiKey)
+}
+
+func (a AuthConfig) APIKey() *AuthAPIKeyConfig {
+	return a.apiKey
+}
+
+// HasBearer reports whether a bearer token is required, validated as a JWT or through introspection.
+func (a AuthConfig) HasBearer() bool {
+	return a.HasJWT() || a.HasIntrospection()
+}
+
+func (a AuthConfig) HasScopes() bool {
+	return checker.IsNotEmpty(a.scopes)
+}
+
+// Scopes returns the scopes that the token must have been granted, all of them are required.
+func (a AuthConfig) Scopes() []string {
+	return a.scopes
+}
+
+func (a AuthJWTConfig) HasJWKSURL() bool {
+	return checker.IsNotEmpty(a.jwksURL)
+}
+
+func (a AuthJWTConfig) JWKSURL() string {
+	return a.jwksURL
+}
+
+func (a AuthJWTConfig) HasJWKSFile() bool {
+	return checker.IsNotEmpty(a.jwksFile)
+}
+
+func (a AuthJWTConfig) JWKSFile() string {
+	return a.jwksFile
+}
+
+// JWKSRefresh returns the interval in which the JWKS document is fetched again to follow key rotation.
+// Default: 1h.
+func (a AuthJWTConfig) JWKSRefresh() time.Duration {
+	if checker.IsGreaterThan(a.jwksRefresh, 0) {
+		return a.jwksRefresh.Time()
+	}
+	return time.Hour
+}
+
+func (a AuthJWTConfig) HasIssuer() bool {
+	return checker.IsNotEmpty(a.issuer)
+}
+
+func (a AuthJWTConfig) Issuer() string {
+	return a.issuer
+}
+
+func (a AuthJWTConfig) HasAudience() bool {
+	return checker.IsNotEmpty(a.audience)
+}
+
+func (a AuthJWTConfig) Audience() []string {
+	return a.audience
+}
+
+// Algorithms returns the signing algorithms accepted in the token header.
+// Default: RS256.
+func (a AuthJWTConfig) Algorithms() []string {
+	if checker.IsNotEmpty(a.algorithms) {
+		return a.algorithms
+	}
+	return []string{"RS256"}
+}
+
+func (a AuthJWTConfig) ClockSkew() time.Duration {
+	return a.clockSkew.Time()
+}
+
+func (a AuthIntrospectionConfig) URL() string {
+	return a.url
+}
+
+func (a AuthIntrospectionConfig) HasClientCredentials() bool {
+	return checker.IsNotEmpty(a.clientID)
+}
+
+func (a AuthIntrospectionConfig) ClientID() string {
+	return a.clientID
+}
+
+func (a AuthIntrospectionConfig) ClientSecret() string {
+	return a.clientSecret
+}
+
+func (a AuthIntrospectionConfig) HasTokenTypeHint() bool {
+	return checker.IsNotEmpty(a.tokenTypeHint)
+}
+
+func (a AuthIntrospectionConfig) TokenTypeHint() string {
+	return a.tokenTypeHint
+}
+
+// CacheTTL returns the maximum time an introspection result is kept in the store, active results expire earlier when
+// the token exp is sooner.
+// Default: 5m.
+func (a AuthIntrospectionConfig) CacheTTL() time.Duration {
+	if checker.IsGreaterThan(a.cacheTTL, 0) {
+		return a.cacheTTL.Time()
+	}
+	return 5 * time.Minute
+}
+
+// Header returns the header name the API key is read from, used when no query param is configured.
+// Default: X-API-Key.
+func (a AuthAPIKeyConfig) Header() string {
+	if checker.IsEmpty(a.header) && checker.IsEmpty(a.query) {
+		return "X-API-Key"
+	}
+	return a.header
+}
+
+func (a AuthAPIKeyConfig) Query() string {
+	return a.query
+}
+
+func (a AuthAPIKeyConfig) Keys() map[string]*APIConsumer {
+	return a.keys
+}
+
+func (a AuthAPIKeyConfig) HasFile() bool {
+	return checker.IsNotEmpty(a.file)
+}
+
+func (a AuthAPIKeyConfig) File() string {
+	return a.file
+}
+
+func (a AuthAPIKeyConfig) Store() bool {
+	return a.store
+}
+
+func (a AuthSignatureConfig) Header() string {
+	return a.header
+}
+
+func (a AuthSignatureConfig) Prefix() string {
+	return a.prefix
+}
+
+// Algorithm returns the hash function used by the HMAC.
+// Default: SHA256.
+func (a AuthSignatureConfig) Algorithm() enum.HMACAlgorithm {
+	if checker.IsEmpty(a.algorithm) {
+		return enum.HMACAlgorithmSHA256
+	}
+	return a.algorithm
+}
+
+// Encoding returns how the signature is encoded in the header.
+// Default: HEX.
+func (a AuthSignatureConfig) Encoding() enum.SignatureEncoding {
+	if checker.IsEmpty(a.encoding) {
+		return enum.SignatureEncodingHex
+	}
+	return a.encoding
+}
+
+func (a AuthSignatureConfig) SecretEnv() string {
+	return a.secretEnv
+}
+
+// Canonical returns the template of the signed string, accepting the {body}, {timestamp} and {header.<name>}
+// placeholders.
+// Default: {timestamp}.{body} when the timestamp is configured, otherwise {body}.
+func (a AuthSignatureConfig) Canonical() string {
+	if checker.IsNotEmpty(a.canonical) {
+		return a.canonical
+	} else if a.HasTimestamp() {
+		return "{timestamp}.{body}"
+	}
+	return "{body}"
+}
+
+func (a AuthSignatureConfig) HasTimestamp() bool {
+	return checker.IsNotEmpty(a.timestampHeader)
+}
+
+func (a AuthSignatureConfig) TimestampHeader() string {
+	return a.timestampHeader
+}
+
+// Tolerance returns the replay window, requests with a timestamp farther than it from now are rejected.
+// Default: 5m.
+func (a AuthSignatureConfig) Tolerance() time.Duration {
+	if checker.IsGreaterThan(a.tolerance, 0) {
+		return a.tolerance.Time()
+	}
+	return 5 * time.Minute
+}
@@ -26,26 +26,30 @@ import (
 )
 
 type BackendCacheEntry struct {
-	Kind        enum.BackendKind    `json:"kind"`
-	Outcome     enum.BackendOutcome `json:"outcome"`
-	Degradation Degradation         `json:"degradation"`
-	Status      ResponseStatus      `json:"status"`
-	Metadata    Metadata            `json:"metadata"`
-	Payload     *Payload            `json:"payload,omitempty"`
-	TTL         Duration            `json:"ttl"`
-	CreatedAt   time.Time           `json:"createdAt"`
+	Kind                 enum.BackendKind    `json:"kind"`
+	Outcome              enum.BackendOutcome `json:"outcome"`
+	Degradation          Degradation         `json:"degradation"`
+	Status               ResponseStatus      `json:"status"`
+	Metadata             Metadata            `json:"metadata"`
+	Payload              *Payload            `json:"payload,omitempty"`
+	TTL                  Duration            `json:"ttl"`
+	StaleWhileRevalidate Duration            `json:"staleWhileRevalidate,omitempty"`
+	StaleIfError         Duration            `json:"staleIfError,omitempty"`
+	CreatedAt            time.Time           `json:"createdAt"`
 }
 
 func NewBackendCacheEntry(config *CacheConfig, response *BackendResponse) *BackendCacheEntry {
 	return &BackendCacheEntry{
-		Kind:        response.Kind(),
-		Outcome:     response.Outcome(),
-		Degradation: response.Degradation(),
-		Status:      response.Status(),
-		Metadata:    response.Metadata(),
-		Payload:     response.Payload(),
-		TTL:         config.TTL(),
-		CreatedAt:   time.Now(),
+		Kind:                 response.Kind(),
+		Outcome:              response.Outcome(),
+		Degradation:          response.Degradation(),
+		Status:               response.Status(),
+		Metadata:             response.Metadata(),
+		Payload:              response.Payload(),
+		TTL:                  config.TTL(),
+		StaleWhileRevalidate: config.StaleWhileRevalidate(),
+		StaleIfError:         config.StaleIfError(),
+		CreatedAt:            time.Now(),
 	}
 }
 
@@ -63,7 +67,7 @@ func (b BackendCacheEntry) Response(duration time.Duration) *BackendResponse {
 	}
 	return NewBackendResponseWithAll(
 		b.Kind,
-		NewCacheInfo(true, b.TTL, NewDuration(b.TTL.Time()-time.Since(b.CreatedAt))),
+		NewCacheInfo(true, b.TTL, NewDuration(max(b.TTL.Time()-b.Age(), 0))),
 		b.Outcome,
 		b.Degradation,
 		duration,
@@ -72,3 +76,23 @@ func (b BackendCacheEntry) Response(duration time.Duration) *BackendResponse {
 		b.Payload,
 	)
 }
+
+func (b BackendCacheEntry) Age() time.Duration {
+	return time.Since(b.CreatedAt)
+}
+
+func (b BackendCacheEntry) IsFresh() bool {
+	return checker.IsLessThan(b.Age(), b.TTL.Time())
+}
+
+// IsStaleWhileRevalidate returns true while the age is inside the TTL plus the stale-while-revalidate window, once
+// the TTL has expired the entry is served while it is refreshed in background.
+func (b BackendCacheEntry) IsStaleWhileRevalidate() bool {
+	return checker.IsLessThan(b.Age(), b.TTL.Time()+b.StaleWhileRevalidate.Time())
+}
+
+// IsStaleIfError returns true while the age is inside the TTL plus the stale-if-error window, once the TTL has
+// expired the entry is served only if the execution fails.
+func (b BackendCacheEntry) IsStaleIfError() bool {
+	return checker.IsLessThan(b.Age(), b.TTL.Time()+b.StaleIfError.Time())
+}
@@ -30,8 +30,11 @@ type BackendConfig struct {
 	dependencies *BackendDependenciesConfig
 	kind         enum.BackendKind
 	cache        *CacheConfig
+	invalidate   *CacheInvalidateConfig
 	http         *BackendHTTPConfig
 	publisher    *BackendPublisherConfig
+	grpc         *BackendGRPCConfig
+	graphQL      *BackendGraphQLConfig
 	response     *BackendResponseConfig
 }
 
@@ -44,8 +47,11 @@ func NewBackendConfig(
 	dependencies *BackendDependenciesConfig,
 	kind enum.BackendKind,
 	cache *CacheConfig,
+	invalidate *CacheInvalidateConfig,
 	http *BackendHTTPConfig,
 	publisher *BackendPublisherConfig,
+	grpc *BackendGRPCConfig,
+	graphQL *BackendGraphQLConfig,
 	response *BackendResponseConfig,
 ) BackendConfig {
 	return BackendConfig{
@@ -57,8 +63,11 @@ func NewBackendConfig(
 		dependencies: dependencies,
 		kind:         kind,
 		cache:        cache,
+		invalidate:   invalidate,
 		http:         http,
 		publisher:    publisher,
+		grpc:         grpc,
+		graphQL:      graphQL,
 		response:     response,
 	}
 }
@@ -99,6 +108,14 @@ func (b *BackendConfig) Cache() *CacheConfig {
 	return b.cache
 }
 
+func (b *BackendConfig) HasInvalidate() bool {
+	return checker.NonNil(b.invalidate)
+}
+
+func (b *BackendConfig) Invalidate() *CacheInvalidateConfig {
+	return b.invalidate
+}
+
 func (b *BackendConfig) HTTP() *BackendHTTPConfig {
 	return b.http
 }
@@ -107,6 +124,14 @@ func (b *BackendConfig) Publisher() *BackendPublisherConfig {
 	return b.publisher
 }
 
+func (b *BackendConfig) GRPC() *BackendGRPCConfig {
+	return b.grpc
+}
+
+func (b *BackendConfig) GraphQL() *BackendGraphQLConfig {
+	return b.graphQL
+}
+
 func (b *BackendConfig) Kind() enum.BackendKind {
 	return b.kind
 }
@@ -119,6 +144,14 @@ func (b *BackendConfig) IsPublisher() bool {
 	return checker.Equals(b.kind, enum.BackendKindPublisher)
 }
 
+func (b *BackendConfig) IsGRPC() bool {
+	return checker.Equals(b.kind, enum.BackendKindGRPC)
+}
+
+func (b *BackendConfig) IsGraphQL() bool {
+	return checker.Equals(b.kind, enum.BackendKindGraphQL)
+}
+
 func (b *BackendConfig) IsBeforeware() bool {
 	return checker.Equals(b.flow, enum.BackendFlowBeforeware)
 }
@@ -160,6 +193,10 @@ func (b *BackendConfig) CountAllDataTransforms() (count int) {
 		count += b.HTTP().CountAllDataTransforms()
 	case enum.BackendKindPublisher:
 		count += b.Publisher().CountAllDataTransforms()
+	case enum.BackendKindGRPC:
+		count += b.GRPC().CountAllDataTransforms()
+	case enum.BackendKindGraphQL:
+		count += b.GraphQL().CountAllDataTransforms()
 	}
 	count += b.CountResponseDataTransforms()
 	return count
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vo
+
+import "github.com/tech4works/checker"
+
+// BackendGraphQLConfig posts a query document to a GraphQL server. The query is sent as configured, the values of the
+// request and the previous responses reach it only through the variables.
+type BackendGraphQLConfig struct {
+	hosts         []string
+	path          string
+	query         string
+	operationName string
+	variables     map[string]any
+	header        *MetadataConfig
+}
+
+func NewBackendGraphQLConfig(
+	hosts []string,
+	path,
+	query,
+	operationName string,
+	variables map[string]any,
+	header *MetadataConfig,
+) *BackendGraphQLConfig {
+	return &BackendGraphQLConfig{
+		hosts:         hosts,
+		path:          path,
+		query:         query,
+		operationName: operationName,
+		variables:     variables,
+		header:        header,
+	}
+}
+
+func (b *BackendGraphQLConfig) Hosts() []string {
+	return b.hosts
+}
+
+func (b *BackendGraphQLConfig) Path() string {
+	return b.path
+}
+
+func (b *BackendGraphQLConfig) Query() string {
+	return b.query
+}
+
+func (b *BackendGraphQLConfig) HasOperationName() bool {
+	return checker.IsNotEmpty(b.operationName)
+}
+
+func (b *BackendGraphQLConfig) OperationName() string {
+	return b.operationName
+}
+
+// Variables returns the variables sent with the query, string values are resolved as dynamic values.
+func (b *BackendGraphQLConfig) Variables() map[string]any {
+	return b.variables
+}
+
+func (b *BackendGraphQLConfig) HasHeader() bool {
+	return checker.NonNil(b.header)
+}
+
+func (b *BackendGraphQLConfig) Header() *MetadataConfig {
+	return b.header
+}
+
+func (b *BackendGraphQLConfig) CountAllDataTransforms() (count int) {
+	if b.HasHeader() {
+		count += b.Header().CountDataTransforms()
+	}
+	return count + len(b.Variables())
+}
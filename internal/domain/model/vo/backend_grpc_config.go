@@ -0,0 +1,77 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BackendGRPCConfig invokes a unary gRPC method, the JSON request body is encoded into the method input message
+// resolved from the descriptor sets.
+type BackendGRPCConfig struct {
+	hosts       []string
+	service     string
+	method      string
+	descriptors []string
+	request     BackendGRPCRequestConfig
+}
+
+// NewBackendGRPCConfig accepts the path in the "package.Service/Method" form, with or without the leading slash.
+func NewBackendGRPCConfig(
+	hosts []string,
+	path string,
+	descriptors []string,
+	request BackendGRPCRequestConfig,
+) *BackendGRPCConfig {
+	service, method, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return &BackendGRPCConfig{
+		hosts:       hosts,
+		service:     service,
+		method:      method,
+		descriptors: descriptors,
+		request:     request,
+	}
+}
+
+func (b *BackendGRPCConfig) Hosts() []string {
+	return b.hosts
+}
+
+func (b *BackendGRPCConfig) Service() string {
+	return b.service
+}
+
+func (b *BackendGRPCConfig) Method() string {
+	return b.method
+}
+
+// FullMethod returns the method name as seen by gRPC, for example "/users.v1.UserService/GetUser".
+func (b *BackendGRPCConfig) FullMethod() string {
+	return fmt.Sprintf("/%s/%s", b.service, b.method)
+}
+
+func (b *BackendGRPCConfig) Descriptors() []string {
+	return b.descriptors
+}
+
+func (b *BackendGRPCConfig) Request() BackendGRPCRequestConfig {
+	return b.request
+}
+
+func (b *BackendGRPCConfig) CountAllDataTransforms() (count int) {
+	return b.Request().CountAllDataTransforms()
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vo
+
+import "github.com/tech4works/checker"
+
+type BackendGRPCRequestConfig struct {
+	metadata *MetadataConfig
+	payload  *PayloadConfig
+}
+
+func NewBackendGRPCRequestConfig(metadata *MetadataConfig, payload *PayloadConfig) BackendGRPCRequestConfig {
+	return BackendGRPCRequestConfig{
+		metadata: metadata,
+		payload:  payload,
+	}
+}
+
+func (b BackendGRPCRequestConfig) HasMetadata() bool {
+	return checker.NonNil(b.metadata)
+}
+
+func (b BackendGRPCRequestConfig) Metadata() *MetadataConfig {
+	return b.metadata
+}
+
+func (b BackendGRPCRequestConfig) HasPayload() bool {
+	return checker.NonNil(b.payload)
+}
+
+func (b BackendGRPCRequestConfig) Payload() *PayloadConfig {
+	return b.payload
+}
+
+func (b BackendGRPCRequestConfig) CountAllDataTransforms() (count int) {
+	if b.HasMetadata() {
+		count += b.Metadata().CountDataTransforms()
+	}
+	if b.HasPayload() {
+		count += b.Payload().CountDataTransforms()
+	}
+	return count
+}
@@ -1,10 +1,14 @@
 package vo
 
+import "github.com/tech4works/checker"
+
 type BackendHTTPConfig struct {
-	hosts   []string
-	path    string
-	method  string
-	request BackendHTTPRequestConfig
+	hosts      []string
+	path       string
+	method     string
+	request    BackendHTTPRequestConfig
+	signing    *BackendSigningConfig
+	credential *CredentialConfig
 }
 
 func NewBackendHTTPConfig(
@@ -12,12 +16,16 @@ func NewBackendHTTPConfig(
 	path,
 	method string,
 	request BackendHTTPRequestConfig,
+	signing *BackendSigningConfig,
+	credential *CredentialConfig,
 ) *BackendHTTPConfig {
 	return &BackendHTTPConfig{
-		hosts:   hosts,
-		path:    path,
-		method:  method,
-		request: request,
+		hosts:      hosts,
+		path:       path,
+		method:     method,
+		request:    request,
+		signing:    signing,
+		credential: credential,
 	}
 }
 
@@ -37,6 +45,22 @@ func (b *BackendHTTPConfig) Request() BackendHTTPRequestConfig {
 	return b.request
 }
 
+func (b *BackendHTTPConfig) HasSigning() bool {
+	return checker.NonNil(b.signing)
+}
+
+func (b *BackendHTTPConfig) Signing() *BackendSigningConfig {
+	return b.signing
+}
+
+func (b *BackendHTTPConfig) HasCredential() bool {
+	return checker.NonNil(b.credential)
+}
+
+func (b *BackendHTTPConfig) Credential() *CredentialConfig {
+	return b.credential
+}
+
 func (b *BackendHTTPConfig) CountAllDataTransforms() (count int) {
 	return b.Request().CountAllDataTransforms()
 }
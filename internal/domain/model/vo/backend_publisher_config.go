@@ -26,6 +26,7 @@ type BackendPublisherConfig struct {
 	path            string
 	groupID         string
 	deduplicationID string
+	routingKey      string
 	delay           Duration
 	message         BackendPublisherMessageConfig
 }
@@ -34,7 +35,8 @@ func NewBackendPublisherConfig(
 	broker enum.BackendBroker,
 	path,
 	groupID,
-	deduplicationID string,
+	deduplicationID,
+	routingKey string,
 	delay Duration,
 	message BackendPublisherMessageConfig,
 ) *BackendPublisherConfig {
@@ -43,6 +45,7 @@ func NewBackendPublisherConfig(
 		path:            path,
 		groupID:         groupID,
 		deduplicationID: deduplicationID,
+		routingKey:      routingKey,
 		delay:           delay,
 		message:         message,
 	}
@@ -71,6 +74,14 @@ func (p BackendPublisherConfig) DeduplicationID() string {
 	return p.deduplicationID
 }
 
+func (p BackendPublisherConfig) HasRoutingKey() bool {
+	return checker.IsNotEmpty(p.routingKey)
+}
+
+func (p BackendPublisherConfig) RoutingKey() string {
+	return p.routingKey
+}
+
 func (p BackendPublisherConfig) Delay() Duration {
 	return p.delay
 }
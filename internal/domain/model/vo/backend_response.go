@@ -183,7 +183,7 @@ func (b *BackendResponse) Map() (map[string]any, error) {
 	}
 
 	switch b.kind {
-	case enum.BackendKindHTTP, enum.BackendKindPublisher:
+	case enum.BackendKindHTTP, enum.BackendKindPublisher, enum.BackendKindGRPC, enum.BackendKindGraphQL:
 		baseMap["header"] = b.Metadata().Map()
 		baseMap["body"] = payload
 	default:
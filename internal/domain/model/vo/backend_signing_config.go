@@ -0,0 +1,147 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+type BackendSigningConfig struct {
+	aws  *BackendSigningAWSConfig
+	hmac *BackendSigningHMACConfig
+}
+
+type BackendSigningAWSConfig struct {
+	service string
+	region  string
+}
+
+type BackendSigningHMACConfig struct {
+	header          string
+	prefix          string
+	algorithm       enum.HMACAlgorithm
+	encoding        enum.SignatureEncoding
+	secretEnv       string
+	canonical       string
+	timestampHeader string
+}
+
+func NewBackendSigningConfig(aws *BackendSigningAWSConfig, hmac *BackendSigningHMACConfig) *BackendSigningConfig {
+	return &BackendSigningConfig{
+		aws:  aws,
+		hmac: hmac,
+	}
+}
+
+func NewBackendSigningAWSConfig(service, region string) *BackendSigningAWSConfig {
+	return &BackendSigningAWSConfig{
+		service: service,
+		region:  region,
+	}
+}
+
+func NewBackendSigningHMACConfig(header, prefix string, algorithm enum.HMACAlgorithm, encoding enum.SignatureEncoding,
+	secretEnv, canonical, timestampHeader string) *BackendSigningHMACConfig {
+	return &BackendSigningHMACConfig{
+		header:          header,
+		prefix:          prefix,
+		algorithm:       algorithm,
+		encoding:        encoding,
+		secretEnv:       secretEnv,
+		canonical:       canonical,
+		timestampHeader: timestampHeader,
+	}
+}
+
+func (b BackendSigningConfig) HasAWS() bool {
+	return checker.NonNil(b.aws)
+}
+
+func (b BackendSigningConfig) AWS() *BackendSigningAWSConfig {
+	return b.aws
+}
+
+func (b BackendSigningConfig) HasHMAC() bool {
+	return checker.NonNil(b.hmac)
+}
+
+func (b BackendSigningConfig) HMAC() *BackendSigningHMACConfig {
+	return b.hmac
+}
+
+func (b BackendSigningAWSConfig) Service() string {
+	return b.service
+}
+
+func (b BackendSigningAWSConfig) HasRegion() bool {
+	return checker.IsNotEmpty(b.region)
+}
+
+// Region returns the region of the signing scope, when empty the region of the default AWS config is used.
+func (b BackendSigningAWSConfig) Region() string {
+	return b.region
+}
+
+func (b BackendSigningHMACConfig) Header() string {
+	return b.header
+}
+
+func (b BackendSigningHMACConfig) Prefix() string {
+	return b.prefix
+}
+
+// Algorithm returns the hash function used by the HMAC.
+// Default: SHA256.
+func (b BackendSigningHMACConfig) Algorithm() enum.HMACAlgorithm {
+	if checker.IsEmpty(b.algorithm) {
+		return enum.HMACAlgorithmSHA256
+	}
+	return b.algorithm
+}
+
+// Encoding returns how the signature is encoded in the header.
+// Default: HEX.
+func (b BackendSigningHMACConfig) Encoding() enum.SignatureEncoding {
+	if checker.IsEmpty(b.encoding) {
+		return enum.SignatureEncodingHex
+	}
+	return b.encoding
+}
+
+func (b BackendSigningHMACConfig) SecretEnv() string {
+	return b.secretEnv
+}
+
+// Canonical returns the template of the signed string, accepting the {method}, {path}, {query}, {body}, {timestamp}
+// and {header.<name>} placeholders.
+// Default: {method}\n{path}\n{query}\n{timestamp}\n{body}.
+func (b BackendSigningHMACConfig) Canonical() string {
+	if checker.IsNotEmpty(b.canonical) {
+		return b.canonical
+	}
+	return "{method}\n{path}\n{query}\n{timestamp}\n{body}"
+}
+
+func (b BackendSigningHMACConfig) HasTimestampHeader() bool {
+	return checker.IsNotEmpty(b.timestampHeader)
+}
+
+func (b BackendSigningHMACConfig) TimestampHeader() string {
+	return b.timestampHeader
+}
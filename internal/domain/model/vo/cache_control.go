@@ -0,0 +1,110 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
+)
+
+// CacheControl holds the caching directives of a request or response, read from the Cache-Control header and, when it
+// does not inform the max-age, from the Expires and Pragma headers.
+type CacheControl struct {
+	noStore   bool
+	noCache   bool
+	private   bool
+	hasMaxAge bool
+	maxAge    time.Duration
+}
+
+func NewCacheControl(metadata Metadata) CacheControl {
+	var cacheControl CacheControl
+	var hasSharedMaxAge bool
+
+	for _, directive := range strings.Split(metadata.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		value = strings.Trim(strings.TrimSpace(value), "\"")
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "no-store":
+			cacheControl.noStore = true
+		case "no-cache":
+			cacheControl.noCache = true
+		case "private":
+			cacheControl.private = true
+		case "max-age":
+			if !hasSharedMaxAge && converter.CouldBeInt(value) {
+				cacheControl.hasMaxAge = true
+				cacheControl.maxAge = time.Duration(converter.ToInt(value)) * time.Second
+			}
+		case "s-maxage":
+			if converter.CouldBeInt(value) {
+				hasSharedMaxAge = true
+				cacheControl.hasMaxAge = true
+				cacheControl.maxAge = time.Duration(converter.ToInt(value)) * time.Second
+			}
+		}
+	}
+
+	if !cacheControl.hasMaxAge && metadata.Exists("Expires") {
+		cacheControl.hasMaxAge = true
+		if expires, err := http.ParseTime(metadata.GetFirst("Expires")); checker.IsNil(err) {
+			cacheControl.maxAge = max(expires.Sub(parseCacheControlDate(metadata)), 0)
+		}
+	}
+	if metadata.NotExists("Cache-Control") && strings.EqualFold(metadata.GetFirst("Pragma"), "no-cache") {
+		cacheControl.noCache = true
+	}
+
+	return cacheControl
+}
+
+// NoStore returns true when the content must not be stored by any cache.
+func (c CacheControl) NoStore() bool {
+	return c.noStore
+}
+
+// NoCache returns true when the content must not be served from a cache without being revalidated.
+func (c CacheControl) NoCache() bool {
+	return c.noCache
+}
+
+// Private returns true when the content is intended for a single user and must not be stored by a shared cache.
+func (c CacheControl) Private() bool {
+	return c.private
+}
+
+func (c CacheControl) HasMaxAge() bool {
+	return c.hasMaxAge
+}
+
+// MaxAge returns how long the content is fresh, the s-maxage taking precedence over the max-age and both over the
+// Expires, which is relative to the Date header when informed. An invalid Expires means already expired.
+func (c CacheControl) MaxAge() time.Duration {
+	return c.maxAge
+}
+
+func parseCacheControlDate(metadata Metadata) time.Time {
+	if date, err := http.ParseTime(metadata.GetFirst("Date")); checker.IsNil(err) {
+		return date
+	}
+	return time.Now()
+}
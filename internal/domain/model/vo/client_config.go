@@ -31,6 +31,7 @@ type ClientConfig struct {
 	idleConnTimeout     Duration
 	circuitBreaker      CircuitBreakerConfig
 	retry               RetryConfig
+	tls                 []ClientTLSConfig
 }
 
 // CircuitBreakerConfig holds circuit breaker settings per backend host.
@@ -54,6 +55,7 @@ func NewClientConfig(
 	idleConnTimeout Duration,
 	cb CircuitBreakerConfig,
 	retry RetryConfig,
+	tls []ClientTLSConfig,
 ) *ClientConfig {
 	return &ClientConfig{
 		timeout:             timeout,
@@ -62,6 +64,7 @@ func NewClientConfig(
 		idleConnTimeout:     idleConnTimeout,
 		circuitBreaker:      cb,
 		retry:               retry,
+		tls:                 tls,
 	}
 }
 
@@ -170,3 +173,9 @@ func (c *ClientConfig) RetryBackoff() time.Duration {
 	}
 	return 100 * time.Millisecond
 }
+
+// TLS returns the client certificates and CAs of the backend hosts that require them, the other hosts use the
+// system trust store.
+func (c *ClientConfig) TLS() []ClientTLSConfig {
+	return c.tls
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import "github.com/tech4works/checker"
+
+// ClientTLSConfig holds the client certificate presented and the CAs trusted on the connections to the listed backend
+// hosts, informed as host or host:port.
+type ClientTLSConfig struct {
+	hosts      []string
+	certFile   string
+	keyFile    string
+	caFile     string
+	serverName string
+}
+
+func NewClientTLSConfig(hosts []string, certFile, keyFile, caFile, serverName string) ClientTLSConfig {
+	return ClientTLSConfig{
+		hosts:      hosts,
+		certFile:   certFile,
+		keyFile:    keyFile,
+		caFile:     caFile,
+		serverName: serverName,
+	}
+}
+
+func (c ClientTLSConfig) Hosts() []string {
+	return c.hosts
+}
+
+func (c ClientTLSConfig) HasCertificate() bool {
+	return checker.IsNotEmpty(c.certFile) && checker.IsNotEmpty(c.keyFile)
+}
+
+func (c ClientTLSConfig) CertFile() string {
+	return c.certFile
+}
+
+func (c ClientTLSConfig) KeyFile() string {
+	return c.keyFile
+}
+
+func (c ClientTLSConfig) HasCAFile() bool {
+	return checker.IsNotEmpty(c.caFile)
+}
+
+func (c ClientTLSConfig) CAFile() string {
+	return c.caFile
+}
+
+func (c ClientTLSConfig) HasServerName() bool {
+	return checker.IsNotEmpty(c.serverName)
+}
+
+func (c ClientTLSConfig) ServerName() string {
+	return c.serverName
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+// ConsumerConfig binds a queue or topic to an endpoint, every message received is executed as a request of it.
+type ConsumerConfig struct {
+	id          string
+	broker      enum.BackendBroker
+	path        string
+	group       string
+	endpoint    *EndpointConfig
+	maxAttempts int
+	backoff     Duration
+	deadLetter  string
+}
+
+func NewConsumerConfig(
+	id string,
+	broker enum.BackendBroker,
+	path,
+	group string,
+	endpoint *EndpointConfig,
+	maxAttempts int,
+	backoff Duration,
+	deadLetter string,
+) ConsumerConfig {
+	return ConsumerConfig{
+		id:          id,
+		broker:      broker,
+		path:        path,
+		group:       group,
+		endpoint:    endpoint,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		deadLetter:  deadLetter,
+	}
+}
+
+// ID returns the consumer identifier, by default the path.
+func (c ConsumerConfig) ID() string {
+	if checker.IsNotEmpty(c.id) {
+		return c.id
+	}
+	return c.path
+}
+
+func (c ConsumerConfig) Broker() enum.BackendBroker {
+	return c.broker
+}
+
+func (c ConsumerConfig) Path() string {
+	return c.path
+}
+
+// Group returns the consumer group, only used by KAFKA.
+// Default: gopen-gateway.
+func (c ConsumerConfig) Group() string {
+	if checker.IsNotEmpty(c.group) {
+		return c.group
+	}
+	return "gopen-gateway"
+}
+
+func (c ConsumerConfig) Endpoint() *EndpointConfig {
+	return c.endpoint
+}
+
+func (c ConsumerConfig) HasMaxAttempts() bool {
+	return checker.IsGreaterThan(c.maxAttempts, 0)
+}
+
+// MaxAttempts returns how many times a message is executed before it is sent to the dead letter.
+// Default: 1.
+func (c ConsumerConfig) MaxAttempts() int {
+	if c.HasMaxAttempts() {
+		return c.maxAttempts
+	}
+	return 1
+}
+
+func (c ConsumerConfig) Backoff() Duration {
+	return c.backoff
+}
+
+func (c ConsumerConfig) HasDeadLetter() bool {
+	return checker.IsNotEmpty(c.deadLetter)
+}
+
+func (c ConsumerConfig) DeadLetter() string {
+	return c.deadLetter
+}
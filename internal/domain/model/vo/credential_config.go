@@ -0,0 +1,90 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"time"
+
+	"github.com/tech4works/checker"
+)
+
+// CredentialConfig obtains, through the OAuth2 client-credentials grant, the bearer token sent to the backends that
+// reference the credential by name.
+type CredentialConfig struct {
+	name          string
+	tokenURL      string
+	clientID      string
+	clientSecret  string
+	scopes        []string
+	audience      string
+	refreshBefore Duration
+}
+
+func NewCredentialConfig(name, tokenURL, clientID, clientSecret string, scopes []string, audience string,
+	refreshBefore Duration) *CredentialConfig {
+	return &CredentialConfig{
+		name:          name,
+		tokenURL:      tokenURL,
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		scopes:        scopes,
+		audience:      audience,
+		refreshBefore: refreshBefore,
+	}
+}
+
+func (c CredentialConfig) Name() string {
+	return c.name
+}
+
+func (c CredentialConfig) TokenURL() string {
+	return c.tokenURL
+}
+
+func (c CredentialConfig) ClientID() string {
+	return c.clientID
+}
+
+func (c CredentialConfig) ClientSecret() string {
+	return c.clientSecret
+}
+
+func (c CredentialConfig) HasScopes() bool {
+	return checker.IsNotEmpty(c.scopes)
+}
+
+func (c CredentialConfig) Scopes() []string {
+	return c.scopes
+}
+
+func (c CredentialConfig) HasAudience() bool {
+	return checker.IsNotEmpty(c.audience)
+}
+
+func (c CredentialConfig) Audience() string {
+	return c.audience
+}
+
+// RefreshBefore returns how long before the expiry the cached token is discarded, so that no backend receives a
+// token that expires in flight.
+// Default: 30s.
+func (c CredentialConfig) RefreshBefore() time.Duration {
+	if checker.IsGreaterThan(c.refreshBefore, 0) {
+		return c.refreshBefore.Time()
+	}
+	return 30 * time.Second
+}
@@ -16,14 +16,25 @@
 
 package vo
 
-import "github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+import (
+	"time"
+
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
 
 type CacheConfig struct {
-	kind  enum.CacheKind
-	read  CacheDecisionConfig
-	write CacheDecisionConfig
-	key   string
-	ttl   Duration
+	kind                 enum.CacheKind
+	read                 CacheDecisionConfig
+	write                CacheDecisionConfig
+	key                  string
+	tags                 []string
+	ttl                  Duration
+	staleWhileRevalidate Duration
+	staleIfError         Duration
+	coalesce             *CacheCoalesceConfig
+	http                 *CacheHTTPConfig
 }
 
 type CacheDecisionConfig struct {
@@ -31,19 +42,45 @@ type CacheDecisionConfig struct {
 	ignoreIf []string
 }
 
+type CacheInvalidateConfig struct {
+	keys     []string
+	patterns []string
+	tags     []string
+}
+
+type CacheCoalesceConfig struct {
+	lockTTL Duration
+	wait    Duration
+}
+
+type CacheHTTPConfig struct {
+	ttl     enum.CacheHTTPTTL
+	noCache *CacheDecisionConfig
+}
+
 func NewCacheConfig(
 	kind enum.CacheKind,
 	read,
 	write CacheDecisionConfig,
 	key string,
-	ttl Duration,
+	tags []string,
+	ttl,
+	staleWhileRevalidate,
+	staleIfError Duration,
+	coalesce *CacheCoalesceConfig,
+	http *CacheHTTPConfig,
 ) *CacheConfig {
 	return &CacheConfig{
-		kind:  kind,
-		read:  read,
-		write: write,
-		key:   key,
-		ttl:   ttl,
+		kind:                 kind,
+		read:                 read,
+		write:                write,
+		key:                  key,
+		tags:                 tags,
+		ttl:                  ttl,
+		staleWhileRevalidate: staleWhileRevalidate,
+		staleIfError:         staleIfError,
+		coalesce:             coalesce,
+		http:                 http,
 	}
 }
 
@@ -54,6 +91,28 @@ func NewCacheDecisionConfig(onlyIf, ignoreIf []string) CacheDecisionConfig {
 	}
 }
 
+func NewCacheInvalidateConfig(keys, patterns, tags []string) *CacheInvalidateConfig {
+	return &CacheInvalidateConfig{
+		keys:     keys,
+		patterns: patterns,
+		tags:     tags,
+	}
+}
+
+func NewCacheCoalesceConfig(lockTTL, wait Duration) *CacheCoalesceConfig {
+	return &CacheCoalesceConfig{
+		lockTTL: lockTTL,
+		wait:    wait,
+	}
+}
+
+func NewCacheHTTPConfig(ttl enum.CacheHTTPTTL, noCache *CacheDecisionConfig) *CacheHTTPConfig {
+	return &CacheHTTPConfig{
+		ttl:     ttl,
+		noCache: noCache,
+	}
+}
+
 func (c CacheConfig) Kind() enum.CacheKind {
 	return c.kind
 }
@@ -70,10 +129,53 @@ func (c CacheConfig) Key() string {
 	return c.key
 }
 
+// Tags returns the templates of the tags indexing the entry, so it can be purged along with every entry sharing a tag.
+func (c CacheConfig) Tags() []string {
+	return c.tags
+}
+
 func (c CacheConfig) TTL() Duration {
 	return c.ttl
 }
 
+// WithTTL returns a copy of the config whose entries live for the TTL informed, used when the HTTP caching directives
+// of the response set or cap the configured one.
+func (c CacheConfig) WithTTL(ttl Duration) *CacheConfig {
+	c.ttl = ttl
+	return &c
+}
+
+// StaleWhileRevalidate returns how long after the TTL the entry is still served while it is refreshed in background.
+func (c CacheConfig) StaleWhileRevalidate() Duration {
+	return c.staleWhileRevalidate
+}
+
+// StaleIfError returns how long after the TTL the entry is still served when the execution fails.
+func (c CacheConfig) StaleIfError() Duration {
+	return c.staleIfError
+}
+
+// StoreTTL returns how long the entry is kept in the store, the TTL plus the widest stale window.
+func (c CacheConfig) StoreTTL() time.Duration {
+	return c.ttl.Time() + max(c.staleWhileRevalidate.Time(), c.staleIfError.Time())
+}
+
+func (c CacheConfig) HasCoalesce() bool {
+	return checker.NonNil(c.coalesce)
+}
+
+func (c CacheConfig) Coalesce() *CacheCoalesceConfig {
+	return c.coalesce
+}
+
+func (c CacheConfig) HasHTTP() bool {
+	return checker.NonNil(c.http)
+}
+
+func (c CacheConfig) HTTP() *CacheHTTPConfig {
+	return c.http
+}
+
 func (c CacheDecisionConfig) OnlyIf() []string {
 	return c.onlyIf
 }
@@ -81,3 +183,56 @@ func (c CacheDecisionConfig) OnlyIf() []string {
 func (c CacheDecisionConfig) IgnoreIf() []string {
 	return c.ignoreIf
 }
+
+// Keys returns the templates of the exact cache keys deleted, resolved as dynamic values.
+func (c *CacheInvalidateConfig) Keys() []string {
+	return c.keys
+}
+
+// Patterns returns the templates of the glob patterns whose matching cache keys are deleted.
+func (c *CacheInvalidateConfig) Patterns() []string {
+	return c.patterns
+}
+
+// Tags returns the templates of the tags whose entries are deleted.
+func (c *CacheInvalidateConfig) Tags() []string {
+	return c.tags
+}
+
+// LockTTL returns how long the lock that elects the replica executing a cache miss is held at most.
+// Default: 5s.
+func (c *CacheCoalesceConfig) LockTTL() time.Duration {
+	if checker.IsGreaterThan(c.lockTTL, 0) {
+		return c.lockTTL.Time()
+	}
+	return 5 * time.Second
+}
+
+// Wait returns how long a replica that lost the lock waits for the entry to be written before executing by itself.
+// Default: the lock TTL.
+func (c *CacheCoalesceConfig) Wait() time.Duration {
+	if checker.IsGreaterThan(c.wait, 0) {
+		return c.wait.Time()
+	}
+	return c.LockTTL()
+}
+
+// TTL returns how the max-age or Expires of the response affects the configured TTL, SET replacing it and CAP only
+// lowering it.
+// Default: CAP.
+func (c *CacheHTTPConfig) TTL() enum.CacheHTTPTTL {
+	if checker.IsNotEmpty(c.ttl) {
+		return c.ttl
+	}
+	return enum.CacheHTTPTTLCap
+}
+
+// HasNoCache returns true when the clients are allowed to bypass the cache read by sending Cache-Control: no-cache.
+func (c *CacheHTTPConfig) HasNoCache() bool {
+	return checker.NonNil(c.noCache)
+}
+
+// NoCache returns the guards deciding whether the client no-cache directive is honored.
+func (c *CacheHTTPConfig) NoCache() *CacheDecisionConfig {
+	return c.noCache
+}
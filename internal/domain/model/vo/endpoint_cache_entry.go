@@ -24,24 +24,28 @@ import (
 )
 
 type EndpointCacheEntry struct {
-	Degradation Degradation       `json:"degradation,omitempty"`
-	Execution   EndpointExecution `json:"execution,omitempty"`
-	Status      ResponseStatus    `json:"status"`
-	Metadata    Metadata          `json:"metadata"`
-	Payload     *Payload          `json:"payload,omitempty"`
-	TTL         Duration          `json:"ttl"`
-	CreatedAt   time.Time         `json:"createdAt"`
+	Degradation          Degradation       `json:"degradation,omitempty"`
+	Execution            EndpointExecution `json:"execution,omitempty"`
+	Status               ResponseStatus    `json:"status"`
+	Metadata             Metadata          `json:"metadata"`
+	Payload              *Payload          `json:"payload,omitempty"`
+	TTL                  Duration          `json:"ttl"`
+	StaleWhileRevalidate Duration          `json:"staleWhileRevalidate,omitempty"`
+	StaleIfError         Duration          `json:"staleIfError,omitempty"`
+	CreatedAt            time.Time         `json:"createdAt"`
 }
 
 func NewEndpointCacheEntry(config *CacheConfig, response *EndpointResponse) *EndpointCacheEntry {
 	return &EndpointCacheEntry{
-		Degradation: response.Degradation(),
-		Execution:   response.Execution(),
-		Status:      response.Status(),
-		Metadata:    response.Metadata(),
-		Payload:     response.Payload(),
-		TTL:         config.TTL(),
-		CreatedAt:   time.Now(),
+		Degradation:          response.Degradation(),
+		Execution:            response.Execution(),
+		Status:               response.Status(),
+		Metadata:             response.Metadata(),
+		Payload:              response.Payload(),
+		TTL:                  config.TTL(),
+		StaleWhileRevalidate: config.StaleWhileRevalidate(),
+		StaleIfError:         config.StaleIfError(),
+		CreatedAt:            time.Now(),
 	}
 }
 
@@ -58,7 +62,7 @@ func (e EndpointCacheEntry) Response() *EndpointResponse {
 		return nil
 	}
 	return NewEndpointResponseWithAll(
-		NewCacheInfo(true, e.TTL, NewDuration(e.TTL.Time()-time.Since(e.CreatedAt))),
+		NewCacheInfo(true, e.TTL, NewDuration(max(e.TTL.Time()-e.Age(), 0))),
 		e.Degradation,
 		e.Execution,
 		e.Status,
@@ -66,3 +70,23 @@ func (e EndpointCacheEntry) Response() *EndpointResponse {
 		e.Payload,
 	)
 }
+
+func (e EndpointCacheEntry) Age() time.Duration {
+	return time.Since(e.CreatedAt)
+}
+
+func (e EndpointCacheEntry) IsFresh() bool {
+	return checker.IsLessThan(e.Age(), e.TTL.Time())
+}
+
+// IsStaleWhileRevalidate returns true while the age is inside the TTL plus the stale-while-revalidate window, once
+// the TTL has expired the entry is served while it is refreshed in background.
+func (e EndpointCacheEntry) IsStaleWhileRevalidate() bool {
+	return checker.IsLessThan(e.Age(), e.TTL.Time()+e.StaleWhileRevalidate.Time())
+}
+
+// IsStaleIfError returns true while the age is inside the TTL plus the stale-if-error window, once the TTL has
+// expired the entry is served only if the execution fails.
+func (e EndpointCacheEntry) IsStaleIfError() bool {
+	return checker.IsLessThan(e.Age(), e.TTL.Time()+e.StaleIfError.Time())
+}
@@ -21,44 +21,58 @@ import (
 	"time"
 
 	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 )
 
 type EndpointConfig struct {
 	execution     EndpointExecutionConfig
+	protocol      enum.Protocol
 	path          string
 	method        string
 	timeout       Duration
 	securityCors  *SecurityCorsConfig
+	auth          *AuthConfig
 	limiter       *LimiterConfig
 	cache         *CacheConfig
+	invalidate    *CacheInvalidateConfig
 	backends      []BackendConfig
 	response      EndpointResponseConfig
 	requestClient *RequestClientConfig
+	grpc          *EndpointGRPCConfig
 }
 
 func NewEndpointConfig(
 	execution EndpointExecutionConfig,
+	protocol enum.Protocol,
 	path,
 	method string,
 	timeout Duration,
 	securityCors *SecurityCorsConfig,
+	auth *AuthConfig,
 	limiter *LimiterConfig,
 	cache *CacheConfig,
+	invalidate *CacheInvalidateConfig,
 	backends []BackendConfig,
 	response EndpointResponseConfig,
 	requestClient *RequestClientConfig,
+	grpc *EndpointGRPCConfig,
 ) EndpointConfig {
 	return EndpointConfig{
 		execution:     execution,
+		protocol:      protocol,
 		path:          path,
 		method:        method,
 		timeout:       timeout,
 		securityCors:  securityCors,
+		auth:          auth,
 		limiter:       limiter,
 		cache:         cache,
+		invalidate:    invalidate,
 		backends:      backends,
 		response:      response,
 		requestClient: requestClient,
+		grpc:          grpc,
 	}
 }
 
@@ -71,10 +85,30 @@ func NewEndpointConfigStatic(path, method string) EndpointConfig {
 	}
 }
 
+// NewEndpointConfigAdmin follows NewEndpointConfigStatic, the administrative routes are always protected by auth.
+func NewEndpointConfigAdmin(path, method string, auth *AuthConfig) EndpointConfig {
+	endpoint := NewEndpointConfigStatic(path, method)
+	endpoint.auth = auth
+	return endpoint
+}
+
 func (e *EndpointConfig) Execution() EndpointExecutionConfig {
 	return e.execution
 }
 
+// Protocol returns the protocol the endpoint is served with.
+// Default: HTTP.
+func (e *EndpointConfig) Protocol() enum.Protocol {
+	if checker.IsEmpty(e.protocol) {
+		return enum.ProtocolHTTP
+	}
+	return e.protocol
+}
+
+func (e *EndpointConfig) IsWebSocket() bool {
+	return checker.Equals(e.Protocol(), enum.ProtocolWebSocket)
+}
+
 func (e *EndpointConfig) Path() string {
 	return e.path
 }
@@ -98,6 +132,14 @@ func (e *EndpointConfig) SecurityCors() *SecurityCorsConfig {
 	return e.securityCors
 }
 
+func (e *EndpointConfig) HasAuth() bool {
+	return checker.NonNil(e.Auth())
+}
+
+func (e *EndpointConfig) Auth() *AuthConfig {
+	return e.auth
+}
+
 func (e *EndpointConfig) HasLimiter() bool {
 	return checker.NonNil(e.Limiter())
 }
@@ -118,6 +160,14 @@ func (e *EndpointConfig) Cache() *CacheConfig {
 	return e.cache
 }
 
+func (e *EndpointConfig) HasInvalidate() bool {
+	return checker.NonNil(e.invalidate)
+}
+
+func (e *EndpointConfig) Invalidate() *CacheInvalidateConfig {
+	return e.invalidate
+}
+
 func (e *EndpointConfig) Backends() []BackendConfig {
 	return e.backends
 }
@@ -167,6 +217,14 @@ func (e *EndpointConfig) RequestClient() *RequestClientConfig {
 	return e.requestClient
 }
 
+func (e *EndpointConfig) HasGRPC() bool {
+	return checker.NonNil(e.grpc)
+}
+
+func (e *EndpointConfig) GRPC() *EndpointGRPCConfig {
+	return e.grpc
+}
+
 func (e *EndpointConfig) Resume() string {
 	return fmt.Sprintf("%s --> \"%s\" (beforeware:%v, backends:%v, afterware:%v, transformations:%v)",
 		e.method, e.path, e.CountBeforewares(), e.CountAfterwares(), e.CountBackends(), e.CountAllDataTransforms())
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EndpointGRPCConfig exposes an endpoint as a unary gRPC method on the inbound gRPC listener.
+type EndpointGRPCConfig struct {
+	service string
+	method  string
+}
+
+// NewEndpointGRPCConfig accepts the method in the "package.Service/Method" form, with or without the leading slash.
+func NewEndpointGRPCConfig(method string) *EndpointGRPCConfig {
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return &EndpointGRPCConfig{
+		service: service,
+		method:  name,
+	}
+}
+
+func (e *EndpointGRPCConfig) Service() string {
+	return e.service
+}
+
+func (e *EndpointGRPCConfig) Method() string {
+	return e.method
+}
+
+// FullMethod returns the method name as seen by gRPC, for example "/users.v1.UserService/GetUser".
+func (e *EndpointGRPCConfig) FullMethod() string {
+	return fmt.Sprintf("/%s/%s", e.service, e.method)
+}
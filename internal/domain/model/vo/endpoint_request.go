@@ -38,6 +38,10 @@ type EndpointRequest struct {
 	operation string
 	metadata  Metadata
 	payload   *Payload
+	tls       *RequestTLS
+
+	authClaims map[string]any
+	consumer   *APIConsumer
 }
 
 func NewHTTPEndpointRequest(
@@ -50,6 +54,7 @@ func NewHTTPEndpointRequest(
 	method string,
 	header Metadata,
 	body *Payload,
+	tls *RequestTLS,
 ) *EndpointRequest {
 	return &EndpointRequest{
 		id:        id,
@@ -62,6 +67,31 @@ func NewHTTPEndpointRequest(
 		metadata:  header,
 		query:     query,
 		payload:   body,
+		tls:       tls,
+	}
+}
+
+func NewWebSocketEndpointRequest(
+	id,
+	traceID,
+	clientIP,
+	url string,
+	path URLPath,
+	query Query,
+	header Metadata,
+	tls *RequestTLS,
+) *EndpointRequest {
+	return &EndpointRequest{
+		id:        id,
+		traceID:   traceID,
+		clientIP:  clientIP,
+		protocol:  enum.ProtocolWebSocket,
+		route:     url,
+		path:      path,
+		operation: http.MethodGet,
+		metadata:  header,
+		query:     query,
+		tls:       tls,
 	}
 }
 
@@ -86,6 +116,27 @@ func NewGRPCEndpointRequest(
 	}
 }
 
+// NewConsumerEndpointRequest builds the request of a message received by a consumer, the route is the queue or topic
+// and the message attributes are the metadata.
+func NewConsumerEndpointRequest(
+	id,
+	traceID,
+	source string,
+	operation string,
+	metadata Metadata,
+	payload *Payload,
+) *EndpointRequest {
+	return &EndpointRequest{
+		id:        id,
+		traceID:   traceID,
+		protocol:  enum.ProtocolConsumer,
+		route:     source,
+		operation: operation,
+		metadata:  metadata,
+		payload:   payload,
+	}
+}
+
 func (r *EndpointRequest) ID() string {
 	return r.id
 }
@@ -134,6 +185,46 @@ func (r *EndpointRequest) Payload() *Payload {
 	return r.payload
 }
 
+func (r *EndpointRequest) HasTLS() bool {
+	return checker.NonNil(r.tls)
+}
+
+func (r *EndpointRequest) TLS() *RequestTLS {
+	return r.tls
+}
+
+func (r *EndpointRequest) HasAuthClaims() bool {
+	return checker.NonNil(r.authClaims)
+}
+
+func (r *EndpointRequest) AuthClaims() map[string]any {
+	return r.authClaims
+}
+
+// WithAuthClaims returns a copy of the request carrying the claims validated by the auth interceptor, exposed to the
+// dynamic values as #request.auth.claims.
+func (r *EndpointRequest) WithAuthClaims(claims map[string]any) *EndpointRequest {
+	request := *r
+	request.authClaims = claims
+	return &request
+}
+
+func (r *EndpointRequest) HasConsumer() bool {
+	return checker.NonNil(r.consumer)
+}
+
+func (r *EndpointRequest) Consumer() *APIConsumer {
+	return r.consumer
+}
+
+// WithConsumer returns a copy of the request identified by the consumer of its API key, exposed to the dynamic values
+// as #request.consumer.
+func (r *EndpointRequest) WithConsumer(consumer *APIConsumer) *EndpointRequest {
+	request := *r
+	request.consumer = consumer
+	return &request
+}
+
 func (r *EndpointRequest) IsHTTP() bool {
 	return checker.Equals(r.protocol, enum.ProtocolHTTP)
 }
@@ -146,6 +237,10 @@ func (r *EndpointRequest) IsGRPC() bool {
 	return checker.Equals(r.protocol, enum.ProtocolGRPC)
 }
 
+func (r *EndpointRequest) IsConsumer() bool {
+	return checker.Equals(r.protocol, enum.ProtocolConsumer)
+}
+
 func (r *EndpointRequest) IsCORS() bool {
 	return r.Metadata().Exists("Origin")
 }
@@ -164,6 +259,20 @@ func (r *EndpointRequest) Map() (string, error) {
 		}
 		payload = bodyMap
 	}
+	var auth any
+	if r.HasAuthClaims() {
+		auth = map[string]any{
+			"claims": r.AuthClaims(),
+		}
+	}
+	var consumer any
+	if r.HasConsumer() {
+		consumer = r.Consumer().Map()
+	}
+	var tls any
+	if r.HasTLS() {
+		tls = r.TLS().Map()
+	}
 	switch r.protocol {
 	case enum.ProtocolHTTP, enum.ProtocolWebSocket:
 		return converter.ToStringWithErr(map[string]any{
@@ -176,6 +285,9 @@ func (r *EndpointRequest) Map() (string, error) {
 			"params":    r.Params().Map(),
 			"query":     r.Query().Map(),
 			"body":      payload,
+			"auth":      auth,
+			"consumer":  consumer,
+			"tls":       tls,
 		})
 	case enum.ProtocolGRPC:
 		return converter.ToStringWithErr(map[string]any{
@@ -185,6 +297,16 @@ func (r *EndpointRequest) Map() (string, error) {
 			"metadata":    r.Metadata().Map(),
 			"operation":   r.Operation(),
 			"payload":     payload,
+			"auth":        auth,
+			"consumer":    consumer,
+		})
+	case enum.ProtocolConsumer:
+		return converter.ToStringWithErr(map[string]any{
+			"id":        r.ID(),
+			"source":    r.Route(),
+			"metadata":  r.Metadata().Map(),
+			"operation": r.Operation(),
+			"payload":   payload,
 		})
 	default:
 		return "", errors.New("unknown protocol to map request")
@@ -17,6 +17,12 @@
 package vo
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/tech4works/checker"
 )
 
@@ -152,3 +158,35 @@ func (e *EndpointResponse) HasPayload() bool {
 func (e *EndpointResponse) Payload() *Payload {
 	return e.payload
 }
+
+// WithValidators returns a copy of the response carrying the ETag, a hash of the payload, and the Last-Modified. When
+// keepBackend, the payload is the one of a single backend, so its validators prevail. The Last-Modified of the
+// previous response is kept while the ETag did not change, otherwise it is the lastModified informed.
+func (e *EndpointResponse) WithValidators(keepBackend bool, previous *EndpointResponse, lastModified time.Time,
+) *EndpointResponse {
+	values := e.metadata.Copy()
+
+	etag := e.metadata.GetFirst("ETag")
+	if !keepBackend || checker.IsEmpty(etag) {
+		var rawBytes []byte
+		if e.HasPayload() {
+			rawBytes = e.payload.RawBytes()
+		}
+		hash := sha256.Sum256(rawBytes)
+		etag = fmt.Sprintf("%q", hex.EncodeToString(hash[:16]))
+	}
+	values[normalizeKey("ETag")] = []string{etag}
+
+	if !keepBackend || e.metadata.NotExists("Last-Modified") {
+		lastModifiedValue := lastModified.UTC().Format(http.TimeFormat)
+		if checker.NonNil(previous) && checker.Equals(previous.metadata.GetFirst("ETag"), etag) &&
+			previous.metadata.Exists("Last-Modified") {
+			lastModifiedValue = previous.metadata.GetFirst("Last-Modified")
+		}
+		values[normalizeKey("Last-Modified")] = []string{lastModifiedValue}
+	}
+
+	response := *e
+	response.metadata = NewMetadata(values)
+	return &response
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+func newValidatorsTestResponse(header map[string][]string, body string) *EndpointResponse {
+	return NewEndpointResponse(NewResponseStatusByValue(enum.ResponseStatusOK), NewMetadata(header),
+		NewPayloadJSON(bytes.NewBufferString(body)))
+}
+
+func TestEndpointResponseWithValidators(t *testing.T) {
+	now := time.Date(2026, 10, 21, 7, 28, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour).Format(http.TimeFormat)
+	backend := map[string][]string{"ETag": {`"backend"`}, "Last-Modified": {earlier}}
+
+	hashed := newValidatorsTestResponse(nil, `{"id":1}`).WithValidators(false, nil, now)
+	etag := hashed.Metadata().GetFirst("ETag")
+	if etag == "" || etag == `"backend"` {
+		t.Fatalf("WithValidators() ETag = %q, want the payload hash", etag)
+	} else if got := hashed.Metadata().GetFirst("Last-Modified"); got != now.Format(http.TimeFormat) {
+		t.Errorf("WithValidators() Last-Modified = %q, want %q", got, now.Format(http.TimeFormat))
+	}
+
+	same := newValidatorsTestResponse(nil, `{"id":1}`).WithValidators(false, hashed, now.Add(time.Hour))
+	if got := same.Metadata().GetFirst("ETag"); got != etag {
+		t.Errorf("WithValidators() ETag = %q, want %q", got, etag)
+	} else if got = same.Metadata().GetFirst("Last-Modified"); got != now.Format(http.TimeFormat) {
+		t.Errorf("WithValidators() Last-Modified = %q, want the previous %q", got, now.Format(http.TimeFormat))
+	}
+
+	changed := newValidatorsTestResponse(nil, `{"id":2}`).WithValidators(false, hashed, now.Add(time.Hour))
+	if got := changed.Metadata().GetFirst("ETag"); got == etag {
+		t.Errorf("WithValidators() ETag = %q, want a new hash", got)
+	} else if got = changed.Metadata().GetFirst("Last-Modified"); got != now.Add(time.Hour).Format(http.TimeFormat) {
+		t.Errorf("WithValidators() Last-Modified = %q, want %q", got, now.Add(time.Hour).Format(http.TimeFormat))
+	}
+
+	kept := newValidatorsTestResponse(backend, `{"id":1}`).WithValidators(true, nil, now)
+	if got := kept.Metadata().GetFirst("ETag"); got != `"backend"` {
+		t.Errorf("WithValidators() ETag = %q, want the backend one", got)
+	} else if got = kept.Metadata().GetFirst("Last-Modified"); got != earlier {
+		t.Errorf("WithValidators() Last-Modified = %q, want the backend %q", got, earlier)
+	}
+
+	replaced := newValidatorsTestResponse(backend, `{"id":1}`).WithValidators(false, nil, now)
+	if got := replaced.Metadata().GetFirst("ETag"); got != etag {
+		t.Errorf("WithValidators() ETag = %q, want the payload hash %q", got, etag)
+	}
+}
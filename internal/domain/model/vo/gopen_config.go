@@ -16,17 +16,29 @@
 
 package vo
 
+import "github.com/tech4works/checker"
+
 type GopenConfig struct {
 	server    *ServerConfig
 	client    *ClientConfig
 	endpoints []EndpointConfig
+	consumers []ConsumerConfig
+	admin     *AdminConfig
 }
 
-func NewGopenConfig(server *ServerConfig, client *ClientConfig, endpoints []EndpointConfig) *GopenConfig {
+func NewGopenConfig(
+	server *ServerConfig,
+	client *ClientConfig,
+	endpoints []EndpointConfig,
+	consumers []ConsumerConfig,
+	admin *AdminConfig,
+) *GopenConfig {
 	return &GopenConfig{
 		server:    server,
 		client:    client,
 		endpoints: endpoints,
+		consumers: consumers,
+		admin:     admin,
 	}
 }
 
@@ -44,3 +56,15 @@ func (g GopenConfig) Client() *ClientConfig {
 func (g GopenConfig) Endpoints() []EndpointConfig {
 	return g.endpoints
 }
+
+func (g GopenConfig) Consumers() []ConsumerConfig {
+	return g.consumers
+}
+
+func (g GopenConfig) HasAdmin() bool {
+	return checker.NonNil(g.admin)
+}
+
+func (g GopenConfig) Admin() *AdminConfig {
+	return g.admin
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vo
+
+import (
+	"fmt"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+type GRPCBackendRequest struct {
+	degradation Degradation
+	host        string
+	service     string
+	method      string
+	descriptors []string
+	metadata    Metadata
+	payload     *Payload
+}
+
+func NewGRPCBackendRequest(
+	degradation Degradation,
+	host,
+	service,
+	method string,
+	descriptors []string,
+	metadata Metadata,
+	payload *Payload,
+) *GRPCBackendRequest {
+	return &GRPCBackendRequest{
+		degradation: degradation,
+		host:        host,
+		service:     service,
+		method:      method,
+		descriptors: descriptors,
+		metadata:    metadata,
+		payload:     payload,
+	}
+}
+
+func (g *GRPCBackendRequest) Degradation() Degradation {
+	return g.degradation
+}
+
+func (g *GRPCBackendRequest) Degraded() bool {
+	return g.Degradation().Any()
+}
+
+func (g *GRPCBackendRequest) MetadataDegraded() bool {
+	return g.Degradation().Has(enum.DegradationKindMetadata)
+}
+
+func (g *GRPCBackendRequest) PayloadDegraded() bool {
+	return g.Degradation().Has(enum.DegradationKindPayload)
+}
+
+func (g *GRPCBackendRequest) Host() string {
+	return g.host
+}
+
+func (g *GRPCBackendRequest) Service() string {
+	return g.service
+}
+
+func (g *GRPCBackendRequest) Method() string {
+	return g.method
+}
+
+// FullMethod returns the method name as seen by gRPC, for example "/users.v1.UserService/GetUser".
+func (g *GRPCBackendRequest) FullMethod() string {
+	return fmt.Sprintf("/%s/%s", g.service, g.method)
+}
+
+func (g *GRPCBackendRequest) Descriptors() []string {
+	return g.descriptors
+}
+
+func (g *GRPCBackendRequest) Metadata() Metadata {
+	return g.metadata
+}
+
+func (g *GRPCBackendRequest) HasPayload() bool {
+	return checker.NonNil(g.payload)
+}
+
+func (g *GRPCBackendRequest) Payload() *Payload {
+	return g.payload
+}
@@ -31,6 +31,7 @@ type HTTPBackendRequest struct {
 	header      Metadata
 	query       Query
 	body        *Payload
+	signing     *BackendSigningConfig
 }
 
 func NewHTTPBackendRequest(
@@ -41,6 +42,7 @@ func NewHTTPBackendRequest(
 	header Metadata,
 	query Query,
 	body *Payload,
+	signing *BackendSigningConfig,
 ) *HTTPBackendRequest {
 	return &HTTPBackendRequest{
 		degradation: degradation,
@@ -50,6 +52,7 @@ func NewHTTPBackendRequest(
 		header:      header,
 		query:       query,
 		body:        body,
+		signing:     signing,
 	}
 }
 
@@ -116,3 +119,21 @@ func (b *HTTPBackendRequest) Body() *Payload {
 func (b *HTTPBackendRequest) HasBody() bool {
 	return checker.NonNil(b.body)
 }
+
+func (b *HTTPBackendRequest) HasSigning() bool {
+	return checker.NonNil(b.signing)
+}
+
+func (b *HTTPBackendRequest) Signing() *BackendSigningConfig {
+	return b.signing
+}
+
+// WithBearer returns a copy of the request carrying the token in the Authorization header.
+func (b *HTTPBackendRequest) WithBearer(token string) *HTTPBackendRequest {
+	header := b.header.Copy()
+	header["Authorization"] = []string{"Bearer " + token}
+
+	request := *b
+	request.header = NewMetadata(header)
+	return &request
+}
@@ -20,11 +20,15 @@ import (
 	"time"
 
 	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 )
 
 type LimiterConfig struct {
-	size *LimiterSizeConfig
-	rate *LimiterRateConfig
+	size              *LimiterSizeConfig
+	rates             []*LimiterRateConfig
+	concurrency       *LimiterConcurrencyConfig
+	globalConcurrency *LimiterConcurrencyConfig
 }
 
 type LimiterSizeConfig struct {
@@ -33,14 +37,40 @@ type LimiterSizeConfig struct {
 }
 
 type LimiterRateConfig struct {
-	capacity int
-	every    Duration
+	onlyIf      []string
+	ignoreIf    []string
+	key         string
+	capacity    int
+	every       Duration
+	distributed *LimiterRateDistributedConfig
+}
+
+type LimiterConcurrencyConfig struct {
+	maxInFlight  int
+	maxQueue     int
+	queueTimeout Duration
+	adaptive     *LimiterConcurrencyAdaptiveConfig
+}
+
+type LimiterConcurrencyAdaptiveConfig struct {
+	maxLatency    Duration
+	maxGoroutines int
+	window        Duration
 }
 
-func NewLimiterConfig(size *LimiterSizeConfig, rate *LimiterRateConfig) *LimiterConfig {
+type LimiterRateDistributedConfig struct {
+	algorithm enum.LimiterAlgorithm
+	onFailure enum.LimiterOnFailure
+	timeout   Duration
+}
+
+func NewLimiterConfig(size *LimiterSizeConfig, rates []*LimiterRateConfig, concurrency,
+	globalConcurrency *LimiterConcurrencyConfig) *LimiterConfig {
 	return &LimiterConfig{
-		size: size,
-		rate: rate,
+		size:              size,
+		rates:             rates,
+		concurrency:       concurrency,
+		globalConcurrency: globalConcurrency,
 	}
 }
 
@@ -57,10 +87,49 @@ func NewLimiterSizeConfig(maxMetadata, maxPayload Bytes) *LimiterSizeConfig {
 	}
 }
 
-func NewLimiterRateConfig(every Duration, capacity int) *LimiterRateConfig {
+func NewLimiterRateConfig(
+	onlyIf,
+	ignoreIf []string,
+	key string,
+	every Duration,
+	capacity int,
+	distributed *LimiterRateDistributedConfig,
+) *LimiterRateConfig {
 	return &LimiterRateConfig{
-		capacity: capacity,
-		every:    every,
+		onlyIf:      onlyIf,
+		ignoreIf:    ignoreIf,
+		key:         key,
+		capacity:    capacity,
+		every:       every,
+		distributed: distributed,
+	}
+}
+
+func NewLimiterConcurrencyConfig(maxInFlight, maxQueue int, queueTimeout Duration,
+	adaptive *LimiterConcurrencyAdaptiveConfig) *LimiterConcurrencyConfig {
+	return &LimiterConcurrencyConfig{
+		maxInFlight:  maxInFlight,
+		maxQueue:     maxQueue,
+		queueTimeout: queueTimeout,
+		adaptive:     adaptive,
+	}
+}
+
+func NewLimiterConcurrencyAdaptiveConfig(maxLatency Duration, maxGoroutines int, window Duration,
+) *LimiterConcurrencyAdaptiveConfig {
+	return &LimiterConcurrencyAdaptiveConfig{
+		maxLatency:    maxLatency,
+		maxGoroutines: maxGoroutines,
+		window:        window,
+	}
+}
+
+func NewLimiterRateDistributedConfig(algorithm enum.LimiterAlgorithm, onFailure enum.LimiterOnFailure,
+	timeout Duration) *LimiterRateDistributedConfig {
+	return &LimiterRateDistributedConfig{
+		algorithm: algorithm,
+		onFailure: onFailure,
+		timeout:   timeout,
 	}
 }
 
@@ -68,8 +137,25 @@ func (l *LimiterConfig) Size() *LimiterSizeConfig {
 	return l.size
 }
 
-func (l *LimiterConfig) Rate() *LimiterRateConfig {
-	return l.rate
+func (l *LimiterConfig) Rates() []*LimiterRateConfig {
+	return l.rates
+}
+
+func (l *LimiterConfig) HasConcurrency() bool {
+	return checker.NonNil(l.concurrency)
+}
+
+func (l *LimiterConfig) Concurrency() *LimiterConcurrencyConfig {
+	return l.concurrency
+}
+
+func (l *LimiterConfig) HasGlobalConcurrency() bool {
+	return checker.NonNil(l.globalConcurrency)
+}
+
+// GlobalConcurrency returns the limit shared by every endpoint of the gateway, declared only at the root.
+func (l *LimiterConfig) GlobalConcurrency() *LimiterConcurrencyConfig {
+	return l.globalConcurrency
 }
 
 func (l *LimiterSizeConfig) MaxMetadata() Bytes {
@@ -86,6 +172,24 @@ func (l *LimiterSizeConfig) MaxPayload() Bytes {
 	return NewBytes("3MB")
 }
 
+func (r *LimiterRateConfig) OnlyIf() []string {
+	return r.onlyIf
+}
+
+func (r *LimiterRateConfig) IgnoreIf() []string {
+	return r.ignoreIf
+}
+
+func (r *LimiterRateConfig) HasKey() bool {
+	return checker.IsNotEmpty(r.key)
+}
+
+// Key returns the dynamic value expression that identifies the bucket of the request, when empty the bucket is
+// identified by the consumer or by the client IP.
+func (r *LimiterRateConfig) Key() string {
+	return r.key
+}
+
 func (r *LimiterRateConfig) Capacity() int {
 	return r.capacity
 }
@@ -97,3 +201,97 @@ func (r *LimiterRateConfig) Every() Duration {
 func (r *LimiterRateConfig) EveryTime() time.Duration {
 	return r.every.Time()
 }
+
+func (r *LimiterRateConfig) HasDistributed() bool {
+	return checker.NonNil(r.distributed)
+}
+
+func (r *LimiterRateConfig) Distributed() *LimiterRateDistributedConfig {
+	return r.distributed
+}
+
+// Algorithm returns the algorithm executed atomically in the shared store.
+// Default: TOKEN_BUCKET.
+func (d *LimiterRateDistributedConfig) Algorithm() enum.LimiterAlgorithm {
+	if checker.IsNotEmpty(d.algorithm) {
+		return d.algorithm
+	}
+	return enum.LimiterAlgorithmTokenBucket
+}
+
+// OnFailure returns what happens to the request when the shared store cannot be reached.
+// Default: LOCAL.
+func (d *LimiterRateDistributedConfig) OnFailure() enum.LimiterOnFailure {
+	if checker.IsNotEmpty(d.onFailure) {
+		return d.onFailure
+	}
+	return enum.LimiterOnFailureLocal
+}
+
+// Timeout returns how long the shared store may take to answer before it is considered unreachable.
+// Default: 100ms.
+func (d *LimiterRateDistributedConfig) Timeout() time.Duration {
+	if checker.IsGreaterThan(d.timeout, 0) {
+		return d.timeout.Time()
+	}
+	return 100 * time.Millisecond
+}
+
+func (c *LimiterConcurrencyConfig) HasMaxInFlight() bool {
+	return checker.IsGreaterThan(c.maxInFlight, 0)
+}
+
+// MaxInFlight returns how many requests the endpoint processes at the same time, zero when unlimited.
+func (c *LimiterConcurrencyConfig) MaxInFlight() int {
+	return c.maxInFlight
+}
+
+// MaxQueue returns how many requests may wait for a free slot once the max in-flight is reached.
+// Default: 0.
+func (c *LimiterConcurrencyConfig) MaxQueue() int {
+	return c.maxQueue
+}
+
+// QueueTimeout returns how long a queued request waits for a free slot.
+// Default: 1s.
+func (c *LimiterConcurrencyConfig) QueueTimeout() time.Duration {
+	if checker.IsGreaterThan(c.queueTimeout, 0) {
+		return c.queueTimeout.Time()
+	}
+	return time.Second
+}
+
+func (c *LimiterConcurrencyConfig) HasAdaptive() bool {
+	return checker.NonNil(c.adaptive)
+}
+
+func (c *LimiterConcurrencyConfig) Adaptive() *LimiterConcurrencyAdaptiveConfig {
+	return c.adaptive
+}
+
+func (a *LimiterConcurrencyAdaptiveConfig) HasMaxLatency() bool {
+	return checker.IsGreaterThan(a.maxLatency, 0)
+}
+
+// MaxLatency returns the p99 latency of the endpoint above which new requests are shed.
+func (a *LimiterConcurrencyAdaptiveConfig) MaxLatency() time.Duration {
+	return a.maxLatency.Time()
+}
+
+func (a *LimiterConcurrencyAdaptiveConfig) HasMaxGoroutines() bool {
+	return checker.IsGreaterThan(a.maxGoroutines, 0)
+}
+
+// MaxGoroutines returns the goroutine count of the gateway above which new requests are shed.
+func (a *LimiterConcurrencyAdaptiveConfig) MaxGoroutines() int {
+	return a.maxGoroutines
+}
+
+// Window returns the period of the latencies considered by the p99.
+// Default: 10s.
+func (a *LimiterConcurrencyAdaptiveConfig) Window() time.Duration {
+	if checker.IsGreaterThan(a.window, 0) {
+		return a.window.Time()
+	}
+	return 10 * time.Second
+}
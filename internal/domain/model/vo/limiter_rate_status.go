@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"time"
+)
+
+// LimiterRateStatus describes the bucket of a rate rule right after the request was counted.
+type LimiterRateStatus struct {
+	allowed    bool
+	limit      int
+	remaining  int
+	window     time.Duration
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+func NewLimiterRateStatus(allowed bool, limit, remaining int, window, reset, retryAfter time.Duration,
+) *LimiterRateStatus {
+	return &LimiterRateStatus{
+		allowed:    allowed,
+		limit:      limit,
+		remaining:  max(remaining, 0),
+		window:     window,
+		reset:      max(reset, 0),
+		retryAfter: max(retryAfter, 0),
+	}
+}
+
+func (l *LimiterRateStatus) Allowed() bool {
+	return l.allowed
+}
+
+func (l *LimiterRateStatus) Limit() int {
+	return l.limit
+}
+
+func (l *LimiterRateStatus) Remaining() int {
+	return l.remaining
+}
+
+// Window returns the period in which the limit is fully granted.
+func (l *LimiterRateStatus) Window() time.Duration {
+	return l.window
+}
+
+// Reset returns how long until the bucket is full again.
+func (l *LimiterRateStatus) Reset() time.Duration {
+	return l.reset
+}
+
+// RetryAfter returns how long until the bucket accepts a new request, zero when the request was allowed.
+func (l *LimiterRateStatus) RetryAfter() time.Duration {
+	return l.retryAfter
+}
@@ -59,10 +59,19 @@ type DeduplicationIDSpec interface {
 	DeduplicationID() string
 }
 
+type RoutingKeySpec interface {
+	HasRoutingKey() bool
+	RoutingKey() string
+}
+
 type AttributesSpec interface {
 	Attributes() map[string]AttributeValueConfig
 }
 
+type VariablesSpec interface {
+	Variables() map[string]any
+}
+
 type HostPipelineSpec interface {
 	HostsSpec
 }
@@ -106,6 +115,14 @@ type DeduplicationIDPipelineSpec interface {
 	DeduplicationIDSpec
 }
 
+type RoutingKeyPipelineSpec interface {
+	RoutingKeySpec
+}
+
 type AttributesPipelineSpec interface {
 	AttributesSpec
 }
+
+type VariablesPipelineSpec interface {
+	VariablesSpec
+}
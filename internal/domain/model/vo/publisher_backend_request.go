@@ -11,6 +11,7 @@ type PublisherBackendRequest struct {
 	path            string
 	groupID         *string
 	deduplicationID *string
+	routingKey      *string
 	delay           Duration
 	attributes      map[string]AttributeValueConfig
 	body            *Payload
@@ -21,7 +22,8 @@ func NewPublisherBackendRequest(
 	broker enum.BackendBroker,
 	path,
 	groupID,
-	deduplicationID string,
+	deduplicationID,
+	routingKey string,
 	delay Duration,
 	attributes map[string]AttributeValueConfig,
 	body *Payload,
@@ -32,6 +34,7 @@ func NewPublisherBackendRequest(
 		path:            path,
 		groupID:         checker.IfEmptyReturns(&groupID, nil),
 		deduplicationID: checker.IfEmptyReturns(&deduplicationID, nil),
+		routingKey:      checker.IfEmptyReturns(&routingKey, nil),
 		delay:           delay,
 		attributes:      attributes,
 		body:            body,
@@ -54,6 +57,10 @@ func (m PublisherBackendRequest) DeduplicationIDDegraded() bool {
 	return m.Degradation().Has(enum.DegradationKindDeduplicationID)
 }
 
+func (m PublisherBackendRequest) RoutingKeyDegraded() bool {
+	return m.Degradation().Has(enum.DegradationKindRoutingKey)
+}
+
 func (m PublisherBackendRequest) AttributesDegraded() bool {
 	return m.Degradation().Has(enum.DegradationKindAttributes)
 }
@@ -86,6 +93,14 @@ func (m PublisherBackendRequest) DeduplicationID() *string {
 	return m.deduplicationID
 }
 
+func (m PublisherBackendRequest) HasRoutingKey() bool {
+	return checker.IsNotEmpty(m.routingKey)
+}
+
+func (m PublisherBackendRequest) RoutingKey() *string {
+	return m.routingKey
+}
+
 func (m PublisherBackendRequest) Delay() Duration {
 	return m.delay
 }
@@ -43,6 +43,14 @@ func (q Query) GetAll(key string) []string {
 	return q.values[key]
 }
 
+func (q Query) GetFirst(key string) string {
+	valuesByKey := q.values[key]
+	if checker.IsNotEmpty(valuesByKey) {
+		return valuesByKey[0]
+	}
+	return ""
+}
+
 func (q Query) Exists(key string) bool {
 	_, ok := q.values[key]
 	return ok
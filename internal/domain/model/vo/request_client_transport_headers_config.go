@@ -56,6 +56,7 @@ type RequestClientTransportHeadersResponseConfig struct {
 	backendCache    *bool
 	executionStatus *bool
 	degradation     *bool
+	rateLimit       *bool
 }
 
 func NewRequestClientTransportHeadersResponseConfig(
@@ -63,12 +64,14 @@ func NewRequestClientTransportHeadersResponseConfig(
 	backendCache *bool,
 	executionStatus *bool,
 	degradation *bool,
+	rateLimit *bool,
 ) RequestClientTransportHeadersResponseConfig {
 	return RequestClientTransportHeadersResponseConfig{
 		cache:           cache,
 		backendCache:    backendCache,
 		executionStatus: executionStatus,
 		degradation:     degradation,
+		rateLimit:       rateLimit,
 	}
 }
 
@@ -100,6 +103,13 @@ func (r RequestClientTransportHeadersResponseConfig) DegradationEnabled() bool {
 	return *r.degradation
 }
 
+func (r RequestClientTransportHeadersResponseConfig) RateLimitEnabled() bool {
+	if checker.IsNil(r.rateLimit) {
+		return true
+	}
+	return *r.rateLimit
+}
+
 // RequestClientTransportHeadersConfig controla quais grupos de transport headers são injetados.
 type RequestClientTransportHeadersConfig struct {
 	request  RequestClientTransportHeadersRequestConfig
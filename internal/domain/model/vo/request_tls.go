@@ -0,0 +1,119 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import "github.com/tech4works/checker"
+
+// RequestTLS is the TLS state of the connection that received the request, exposed to the dynamic values as
+// #request.tls. The peer fields are only filled when the client presented a certificate.
+type RequestTLS struct {
+	version     string
+	cipherSuite string
+	serverName  string
+	verified    bool
+	subject     string
+	commonName  string
+	issuer      string
+	serial      string
+	dnsNames    []string
+	emails      []string
+	ips         []string
+	uris        []string
+}
+
+func NewRequestTLS(version, cipherSuite, serverName string) *RequestTLS {
+	return &RequestTLS{
+		version:     version,
+		cipherSuite: cipherSuite,
+		serverName:  serverName,
+	}
+}
+
+// WithPeer returns a copy of the TLS state carrying the client certificate, verified informs whether it was chained
+// to the configured client CA bundle.
+func (r *RequestTLS) WithPeer(verified bool, subject, commonName, issuer, serial string, dnsNames, emails, ips,
+	uris []string) *RequestTLS {
+	requestTLS := *r
+	requestTLS.verified = verified
+	requestTLS.subject = subject
+	requestTLS.commonName = commonName
+	requestTLS.issuer = issuer
+	requestTLS.serial = serial
+	requestTLS.dnsNames = dnsNames
+	requestTLS.emails = emails
+	requestTLS.ips = ips
+	requestTLS.uris = uris
+	return &requestTLS
+}
+
+func (r *RequestTLS) Version() string {
+	return r.version
+}
+
+func (r *RequestTLS) CipherSuite() string {
+	return r.cipherSuite
+}
+
+func (r *RequestTLS) ServerName() string {
+	return r.serverName
+}
+
+func (r *RequestTLS) HasPeer() bool {
+	return checker.IsNotEmpty(r.subject)
+}
+
+func (r *RequestTLS) Verified() bool {
+	return r.verified
+}
+
+func (r *RequestTLS) Subject() string {
+	return r.subject
+}
+
+func (r *RequestTLS) CommonName() string {
+	return r.commonName
+}
+
+func (r *RequestTLS) Issuer() string {
+	return r.issuer
+}
+
+func (r *RequestTLS) Serial() string {
+	return r.serial
+}
+
+func (r *RequestTLS) Map() map[string]any {
+	result := map[string]any{
+		"version":      r.version,
+		"cipher-suite": r.cipherSuite,
+		"server-name":  r.serverName,
+		"verified":     r.verified,
+	}
+	if r.HasPeer() {
+		result["subject"] = r.subject
+		result["common-name"] = r.commonName
+		result["issuer"] = r.issuer
+		result["serial"] = r.serial
+		result["sans"] = map[string]any{
+			"dns":   r.dnsNames,
+			"email": r.emails,
+			"ip":    r.ips,
+			"uri":   r.uris,
+		}
+	}
+	return result
+}
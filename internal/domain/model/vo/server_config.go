@@ -30,15 +30,20 @@ type ServerConfig struct {
 	readHeaderTimeout Duration
 	idleTimeout       Duration
 	keepAlive         bool
+	grpc              *ServerGRPCConfig
+	tls               *ServerTLSConfig
 }
 
-func NewServerConfig(readTimeout, writeTimeout, readHeaderTimeout, idleTimeout Duration, keepAlive bool) *ServerConfig {
+func NewServerConfig(readTimeout, writeTimeout, readHeaderTimeout, idleTimeout Duration, keepAlive bool,
+	grpc *ServerGRPCConfig, tls *ServerTLSConfig) *ServerConfig {
 	return &ServerConfig{
 		readTimeout:       readTimeout,
 		writeTimeout:      writeTimeout,
 		readHeaderTimeout: readHeaderTimeout,
 		idleTimeout:       idleTimeout,
 		keepAlive:         keepAlive,
+		grpc:              grpc,
+		tls:               tls,
 	}
 }
 
@@ -85,3 +90,23 @@ func (s *ServerConfig) IdleTimeout() time.Duration {
 func (s *ServerConfig) KeepAlive() bool {
 	return s.keepAlive
 }
+
+// HasGRPC returns whether the inbound gRPC listener is enabled.
+// Default: false. The gRPC listener only starts when the server.grpc section is configured.
+func (s *ServerConfig) HasGRPC() bool {
+	return checker.NonNil(s.grpc)
+}
+
+func (s *ServerConfig) GRPC() *ServerGRPCConfig {
+	return s.grpc
+}
+
+// HasTLS returns whether the HTTP listener terminates TLS.
+// Default: false. The listener only serves TLS when the server.tls section is configured.
+func (s *ServerConfig) HasTLS() bool {
+	return checker.NonNil(s.tls)
+}
+
+func (s *ServerConfig) TLS() *ServerTLSConfig {
+	return s.tls
+}
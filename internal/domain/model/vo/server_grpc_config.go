@@ -0,0 +1,46 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import "github.com/tech4works/checker"
+
+// ServerGRPCConfig holds the inbound gRPC listener settings. Services are not compiled into the gateway,
+// they are resolved at runtime from the protobuf descriptor sets (protoc --descriptor_set_out).
+type ServerGRPCConfig struct {
+	port        int
+	descriptors []string
+}
+
+func NewServerGRPCConfig(port int, descriptors []string) *ServerGRPCConfig {
+	return &ServerGRPCConfig{
+		port:        port,
+		descriptors: descriptors,
+	}
+}
+
+// Port returns the TCP port of the gRPC listener.
+// Default: 9090 (common convention for gRPC services).
+func (s *ServerGRPCConfig) Port() int {
+	if checker.IsGreaterThan(s.port, 0) {
+		return s.port
+	}
+	return 9090
+}
+
+func (s *ServerGRPCConfig) Descriptors() []string {
+	return s.descriptors
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+// ServerTLSConfig holds the TLS termination settings of the HTTP listener. The files are read again whenever their
+// modification time changes, so renewed certificates are served without restarting the gateway.
+type ServerTLSConfig struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	clientAuth   enum.TLSClientAuth
+}
+
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string, clientAuth enum.TLSClientAuth) *ServerTLSConfig {
+	return &ServerTLSConfig{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		clientAuth:   clientAuth,
+	}
+}
+
+func (s *ServerTLSConfig) CertFile() string {
+	return s.certFile
+}
+
+func (s *ServerTLSConfig) KeyFile() string {
+	return s.keyFile
+}
+
+func (s *ServerTLSConfig) HasClientCAFile() bool {
+	return checker.IsNotEmpty(s.clientCAFile)
+}
+
+func (s *ServerTLSConfig) ClientCAFile() string {
+	return s.clientCAFile
+}
+
+// ClientAuth returns the policy applied to the client certificates, verified against the client CA bundle.
+// Default: REQUIRE when a client CA bundle is configured, otherwise NONE.
+func (s *ServerTLSConfig) ClientAuth() enum.TLSClientAuth {
+	if checker.IsNotEmpty(s.clientAuth) {
+		return s.clientAuth
+	} else if s.HasClientCAFile() {
+		return enum.TLSClientAuthRequire
+	}
+	return enum.TLSClientAuthNone
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vo
+
+import (
+	"bytes"
+
+	"github.com/tech4works/checker"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+// WebSocketMessage is a single data frame exchanged between the client and the backend. Text frames holding a JSON
+// document are typed as JSON, so they can go through the payload pipeline like any other body.
+type WebSocketMessage struct {
+	messageType enum.WebSocketMessageType
+	payload     *Payload
+}
+
+func NewWebSocketMessage(messageType enum.WebSocketMessageType, data []byte) *WebSocketMessage {
+	contentType := NewContentType("application/octet-stream")
+	if checker.Equals(messageType, enum.WebSocketMessageTypeText) && checker.IsJSON(data) {
+		contentType = NewContentTypeJSON()
+	} else if checker.Equals(messageType, enum.WebSocketMessageTypeText) {
+		contentType = NewContentTypeTextPlain()
+	}
+	return &WebSocketMessage{
+		messageType: messageType,
+		payload:     NewPayloadWithContentType(contentType, bytes.NewBuffer(data)),
+	}
+}
+
+func NewWebSocketMessageWithPayload(messageType enum.WebSocketMessageType, payload *Payload) *WebSocketMessage {
+	return &WebSocketMessage{
+		messageType: messageType,
+		payload:     payload,
+	}
+}
+
+func (w *WebSocketMessage) Type() enum.WebSocketMessageType {
+	return w.messageType
+}
+
+func (w *WebSocketMessage) IsText() bool {
+	return checker.Equals(w.messageType, enum.WebSocketMessageTypeText)
+}
+
+func (w *WebSocketMessage) IsJSON() bool {
+	return w.HasPayload() && w.Payload().ContentType().IsJSON()
+}
+
+func (w *WebSocketMessage) HasPayload() bool {
+	return checker.NonNil(w.payload)
+}
+
+func (w *WebSocketMessage) Payload() *Payload {
+	return w.payload
+}
+
+func (w *WebSocketMessage) Bytes() []byte {
+	if !w.HasPayload() {
+		return nil
+	}
+	return w.Payload().RawBytes()
+}
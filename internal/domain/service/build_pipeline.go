@@ -1,7 +1,9 @@
 package service
 
 import (
+	"encoding/json"
 	"math/rand"
+	"strings"
 
 	"github.com/tech4works/checker"
 	"github.com/tech4works/converter"
@@ -255,6 +257,28 @@ func (p BuildPipeline) ApplyDeduplicationID(
 		})
 }
 
+func (p BuildPipeline) ApplyRoutingKey(
+	spec vo.RoutingKeyPipelineSpec,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (string, []error) {
+	if checker.IsNil(spec) || !spec.HasRoutingKey() {
+		return "", nil
+	}
+	return apply(
+		spec.RoutingKey(),
+		step[string]{
+			label: "get-dynamic-value",
+			run: func(in string) (string, []error) {
+				value, allErrs := p.dynamicValueService.Get(in, request, history)
+				if checker.IsNotEmpty(allErrs) {
+					allErrs = errors.NewByChainAsSlicef(allErrs, "pipeline failed: op=build-routing-key value=%s", in)
+				}
+				return value, allErrs
+			},
+		})
+}
+
 func (p BuildPipeline) ApplyAttributes(
 	spec vo.AttributesPipelineSpec,
 	request *vo.EndpointRequest,
@@ -287,6 +311,90 @@ func (p BuildPipeline) ApplyAttributes(
 	)
 }
 
+func (p BuildPipeline) ApplyVariables(
+	spec vo.VariablesPipelineSpec,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) (map[string]any, []error) {
+	if checker.IsNil(spec) || checker.IsEmpty(spec.Variables()) {
+		return nil, nil
+	}
+	return apply(
+		spec.Variables(),
+		step[map[string]any]{
+			label: "build-variables",
+			run: func(in map[string]any) (map[string]any, []error) {
+				variables := make(map[string]any, len(in))
+				allErrs := make([]error, 0)
+
+				for key, value := range in {
+					resolved, errs := p.resolveVariable(value, request, history)
+					if checker.IsNotEmpty(errs) {
+						allErrs = append(allErrs, errors.NewByChainf(errs,
+							"pipeline failed: op=build-variable key=%s value=%v", key, value))
+						continue
+					}
+					variables[key] = resolved
+				}
+
+				return variables, allErrs
+			},
+		},
+	)
+}
+
+// resolveVariable evaluates the dynamic values of every string, also inside objects and arrays. A single value
+// expression keeps the JSON type of its source, so "#request.body.limit" is sent as a number, while
+// "#request.header.X-Id.0" with "007" remains a string. The other resolved values holding a JSON object or array
+// keep their type, the remaining ones are strings.
+func (p BuildPipeline) resolveVariable(value any, request *vo.EndpointRequest, history *aggregate.History) (
+	any, []error) {
+	switch typed := value.(type) {
+	case string:
+		resolved, errs := p.dynamicValueService.GetTyped(typed, request, history)
+		if checker.IsNotEmpty(errs) {
+			return resolved, errs
+		} else if resolvedString, ok := resolved.(string); ok && checker.NotEquals(resolvedString, typed) {
+			return p.parseVariableComposite(resolvedString), nil
+		}
+		return resolved, nil
+	case map[string]any:
+		var allErrs []error
+		result := make(map[string]any, len(typed))
+		for key, item := range typed {
+			resolved, errs := p.resolveVariable(item, request, history)
+			allErrs = append(allErrs, errs...)
+			result[key] = resolved
+		}
+		return result, allErrs
+	case []any:
+		var allErrs []error
+		result := make([]any, len(typed))
+		for i, item := range typed {
+			resolved, errs := p.resolveVariable(item, request, history)
+			allErrs = append(allErrs, errs...)
+			result[i] = resolved
+		}
+		return result, allErrs
+	default:
+		return value, nil
+	}
+}
+
+// parseVariableComposite decodes the resolved value only when it is a JSON object or array.
+func (p BuildPipeline) parseVariableComposite(resolved string) any {
+	trimmed := strings.TrimSpace(resolved)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return resolved
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(trimmed), &parsed); checker.NonNil(err) {
+		return resolved
+	}
+	return parsed
+}
+
 type step[T any] struct {
 	label string
 	run   func(T) (T, []error)
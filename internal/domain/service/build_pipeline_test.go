@@ -0,0 +1,76 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/jsonpath"
+)
+
+type buildPipelineTestVariables map[string]any
+
+func (b buildPipelineTestVariables) Variables() map[string]any {
+	return b
+}
+
+func TestBuildPipelineApplyVariables(t *testing.T) {
+	pipeline := NewBuildPipeline(nil, nil, nil, nil, nil, nil, nil, nil, NewDynamicValue(jsonpath.New()))
+	request := vo.NewHTTPEndpointRequest("id", "trace", "10.0.0.1", "/users", vo.NewURLPath("/users", nil),
+		vo.NewEmptyQuery(), "POST", vo.NewMetadata(map[string][]string{"X-Id": {"007"}}),
+		vo.NewPayloadJSON(bytes.NewBufferString(`{"limit":10,"ratio":0.5,"active":true,"ids":[1,2],"name":"ana"}`)),
+		nil)
+
+	spec := buildPipelineTestVariables{
+		"limit":   "#request.body.limit",
+		"ratio":   "#request.body.ratio",
+		"active":  "#request.body.active",
+		"ids":     "#request.body.ids",
+		"name":    "#request.body.name",
+		"id":      "#request.header.X-Id.0",
+		"label":   "user #request.body.name",
+		"nested":  map[string]any{"limit": "#request.body.limit"},
+		"missing": "#request.body.missing",
+		"static":  5,
+	}
+
+	variables, errs := pipeline.ApplyVariables(spec, request, nil)
+	if len(errs) > 0 {
+		t.Fatalf("ApplyVariables() errs = %v, want none", errs)
+	}
+
+	want := map[string]any{
+		"limit":   float64(10),
+		"ratio":   0.5,
+		"active":  true,
+		"ids":     []any{float64(1), float64(2)},
+		"name":    "ana",
+		"id":      "007",
+		"label":   "user ana",
+		"nested":  map[string]any{"limit": float64(10)},
+		"missing": "#request.body.missing",
+		"static":  5,
+	}
+	for key, wantValue := range want {
+		if got := variables[key]; !reflect.DeepEqual(got, wantValue) {
+			t.Errorf("ApplyVariables() %s = %#v, want %#v", key, got, wantValue)
+		}
+	}
+}
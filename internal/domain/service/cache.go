@@ -19,6 +19,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/tech4works/checker"
 	"github.com/tech4works/converter"
@@ -26,9 +30,14 @@ import (
 
 	"github.com/tech4works/gopen-gateway/internal/domain"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/aggregate"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
 )
 
+// purgeKeyMaxLength bounds the keys and patterns received by the purge, they come from the admin query and are matched
+// against every key of the store.
+const purgeKeyMaxLength = 512
+
 type cache struct {
 	dynamicValueService DynamicValue
 	store               domain.Store
@@ -49,6 +58,19 @@ type Cache interface {
 		request *vo.EndpointRequest,
 		history *aggregate.History,
 	) error
+	Key(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (string, error)
+	ShouldRead(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (bool, error)
+	ShouldWrite(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (bool, error)
+	ApplyDirectives(config *vo.CacheConfig, metadata ...vo.Metadata) (*vo.CacheConfig, bool)
+	Lock(ctx context.Context, config *vo.CacheConfig, key string) (string, bool, error)
+	Unlock(ctx context.Context, key, token string) error
+	Purge(ctx context.Context, keys, patterns, tags []string) error
+	Invalidate(
+		ctx context.Context,
+		config *vo.CacheInvalidateConfig,
+		request *vo.EndpointRequest,
+		history *aggregate.History,
+	) error
 }
 
 func NewCache(dynamicValueService DynamicValue, store domain.Store) Cache {
@@ -65,7 +87,7 @@ func (c cache) Read(
 	history *aggregate.History,
 	dest any,
 ) error {
-	shouldRun, err := c.evalCacheGuards("read", config.Read(), request, history)
+	shouldRun, err := c.ShouldRead(config, request, history)
 	if checker.NonNil(err) {
 		return errors.Inheritf(err, "cache failed: op=eval-guards from=write")
 	} else if !shouldRun {
@@ -94,7 +116,7 @@ func (c cache) Write(
 	request *vo.EndpointRequest,
 	history *aggregate.History,
 ) error {
-	shouldRun, err := c.evalCacheGuards("write", config.Write(), request, history)
+	shouldRun, err := c.ShouldWrite(config, request, history)
 	if checker.NonNil(err) {
 		return errors.Inheritf(err, "cache failed: op=eval-guards from=write")
 	} else if !shouldRun {
@@ -111,14 +133,173 @@ func (c cache) Write(
 		return errors.Inheritf(err, "cache failed: op=build-data-entry")
 	}
 
-	err = c.store.Set(ctx, key, entry, config.TTL().Time())
+	err = c.store.Set(ctx, key, entry, config.StoreTTL())
 	if checker.NonNil(err) {
 		return errors.Inheritf(err, "cache failed: unexpected error writing cache key=%s kind=%s", key, config.Kind())
+	} else if checker.IsEmpty(config.Tags()) {
+		return nil
+	}
+
+	tags, err := c.resolveTags(config.Tags(), request, history)
+	if checker.NonNil(err) {
+		return err
+	} else if checker.IsEmpty(tags) {
+		return nil
+	}
+
+	tagKeys := make([]string, len(tags))
+	for i, tag := range tags {
+		tagKeys[i] = c.buildTagKey(tag)
+	}
+
+	err = c.store.Tag(ctx, key, tagKeys, config.StoreTTL())
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "cache failed: unexpected error tagging cache key=%s kind=%s", key, config.Kind())
+	}
+
+	return nil
+}
+
+func (c cache) Key(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (string, error) {
+	return c.buildKey(config, request, history)
+}
+
+// ShouldRead returns false when the read guards are not satisfied or when the client bypasses the cache with the
+// no-cache directive, allowed only by the HTTP caching mode.
+func (c cache) ShouldRead(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (bool,
+	error) {
+	shouldRun, err := c.evalCacheGuards("read", config.Read(), request, history)
+	if checker.NonNil(err) || !shouldRun {
+		return false, err
+	} else if !config.HasHTTP() || !config.HTTP().HasNoCache() || !vo.NewCacheControl(request.Metadata()).NoCache() {
+		return true, nil
+	}
+
+	bypass, err := c.evalCacheGuards("no-cache", *config.HTTP().NoCache(), request, history)
+	if checker.NonNil(err) {
+		return false, err
+	}
+	return !bypass, nil
+}
+
+func (c cache) ShouldWrite(config *vo.CacheConfig, request *vo.EndpointRequest, history *aggregate.History) (bool,
+	error) {
+	return c.evalCacheGuards("write", config.Write(), request, history)
+}
+
+// ApplyDirectives returns the config with the TTL set or capped by the max-age of the responses metadata, the smallest
+// one prevailing, and false when any of them forbids storing with no-store or private, or is already expired.
+func (c cache) ApplyDirectives(config *vo.CacheConfig, metadata ...vo.Metadata) (*vo.CacheConfig, bool) {
+	if !config.HasHTTP() {
+		return config, true
+	}
+
+	var maxAge *time.Duration
+	for _, m := range metadata {
+		cacheControl := vo.NewCacheControl(m)
+		if cacheControl.NoStore() || cacheControl.Private() {
+			return config, false
+		} else if cacheControl.HasMaxAge() && (checker.IsNil(maxAge) || cacheControl.MaxAge() < *maxAge) {
+			age := cacheControl.MaxAge()
+			maxAge = &age
+		}
+	}
+	if checker.IsNil(maxAge) {
+		return config, true
+	}
+
+	ttl := *maxAge
+	if checker.Equals(config.HTTP().TTL(), enum.CacheHTTPTTLCap) {
+		ttl = min(ttl, config.TTL().Time())
+	}
+	if checker.IsLessThanOrEqual(ttl, 0) {
+		return config, false
+	}
+
+	return config.WithTTL(vo.NewDuration(ttl)), true
+}
+
+// Lock elects the single execution of a cache miss across the replicas sharing the store, it returns false when the
+// key is already locked by another execution. The token returned identifies the holder and is required to unlock.
+func (c cache) Lock(ctx context.Context, config *vo.CacheConfig, key string) (string, bool, error) {
+	token := uuid.New().String()
+
+	ok, err := c.store.SetNX(ctx, c.buildLockKey(key), token, config.Coalesce().LockTTL())
+	if checker.NonNil(err) {
+		return "", false, errors.Inheritf(err, "cache failed: unexpected error locking cache key=%s", key)
 	}
+	return token, ok, nil
+}
 
+// Unlock releases the lock only while it is still held by the token, so an execution outliving the lock TTL never
+// releases the lock taken meanwhile by another replica.
+func (c cache) Unlock(ctx context.Context, key, token string) error {
+	err := c.store.DelIfEquals(ctx, c.buildLockKey(key), token)
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "cache failed: unexpected error unlocking cache key=%s", key)
+	}
 	return nil
 }
 
+// Purge deletes the exact keys, the keys matching the glob patterns and the keys carrying the tags, keys and patterns
+// must start with a cache kind so the other entries sharing the store are never reached and are limited to
+// purgeKeyMaxLength.
+func (c cache) Purge(ctx context.Context, keys, patterns, tags []string) error {
+	for _, key := range append(append([]string{}, keys...), patterns...) {
+		if checker.IsGreaterThan(len(key), purgeKeyMaxLength) {
+			return domain.NewErrCachePurgeInvalid(key[:purgeKeyMaxLength],
+				fmt.Sprintf("must have at most %d characters", purgeKeyMaxLength))
+		} else if !c.isPurgeKeyValid(key) {
+			return domain.NewErrCachePurgeInvalid(key, "must start with a cache kind")
+		}
+	}
+
+	for _, key := range keys {
+		err := c.store.Del(ctx, key)
+		if checker.NonNil(err) {
+			return errors.Inheritf(err, "cache failed: unexpected error purging cache key=%s", key)
+		}
+	}
+	for _, pattern := range patterns {
+		err := c.store.DelByPattern(ctx, pattern)
+		if checker.NonNil(err) {
+			return errors.Inheritf(err, "cache failed: unexpected error purging cache pattern=%s", pattern)
+		}
+	}
+	for _, tag := range tags {
+		err := c.store.DelByTag(ctx, c.buildTagKey(tag))
+		if checker.NonNil(err) {
+			return errors.Inheritf(err, "cache failed: unexpected error purging cache tag=%s", tag)
+		}
+	}
+
+	return nil
+}
+
+func (c cache) Invalidate(
+	ctx context.Context,
+	config *vo.CacheInvalidateConfig,
+	request *vo.EndpointRequest,
+	history *aggregate.History,
+) error {
+	keys, err := c.resolveTemplates(config.Keys(), request, history)
+	if checker.NonNil(err) {
+		return err
+	}
+
+	patterns, err := c.resolveTemplates(config.Patterns(), request, history)
+	if checker.NonNil(err) {
+		return err
+	}
+
+	tags, err := c.resolveTags(config.Tags(), request, history)
+	if checker.NonNil(err) {
+		return err
+	}
+
+	return c.Purge(ctx, keys, patterns, tags)
+}
+
 func (c cache) evalCacheGuards(
 	operation string,
 	decision vo.CacheDecisionConfig,
@@ -139,3 +320,63 @@ func (c cache) buildKey(config *vo.CacheConfig, request *vo.EndpointRequest, his
 	}
 	return fmt.Sprintf("%s:%s", config.Kind(), key), nil
 }
+
+func (c cache) buildLockKey(key string) string {
+	return fmt.Sprintf("LOCK:%s", key)
+}
+
+func (c cache) buildTagKey(tag string) string {
+	return fmt.Sprintf("TAG:%s", tag)
+}
+
+// resolveTags expands each template into one tag per item when its expression resolves to an array, the literal text
+// before the expression prefixing each of them. The empty ones and the ones whose expression was not found are
+// dropped, so an entry is never indexed under the raw template.
+func (c cache) resolveTags(templates []string, request *vo.EndpointRequest, history *aggregate.History) (
+	[]string, error) {
+	var result []string
+	for _, template := range templates {
+		prefix, expr := template, ""
+		if i := strings.Index(template, "#"); checker.IsGreaterThanOrEqual(i, 0) {
+			prefix, expr = template[:i], template[i:]
+		}
+		if checker.IsEmpty(expr) {
+			result = append(result, prefix)
+			continue
+		}
+
+		values, errs := c.dynamicValueService.GetAsSliceOfString(expr, request, history)
+		if checker.IsNotEmpty(errs) {
+			return nil, errors.NewByChainf(errs, "cache failed: op=build-tag")
+		}
+		for _, value := range values {
+			if checker.IsEmpty(value) || checker.Equals(value, expr) {
+				continue
+			}
+			result = append(result, prefix+value)
+		}
+	}
+	return result, nil
+}
+
+func (c cache) resolveTemplates(templates []string, request *vo.EndpointRequest, history *aggregate.History) (
+	[]string, error) {
+	var result []string
+	for _, template := range templates {
+		value, errs := c.dynamicValueService.Get(template, request, history)
+		if checker.IsNotEmpty(errs) {
+			return nil, errors.NewByChainf(errs, "cache failed: op=build-invalidate-key")
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func (c cache) isPurgeKeyValid(key string) bool {
+	for _, kind := range []enum.CacheKind{enum.CacheKindEndpoint, enum.CacheKindBackend} {
+		if strings.HasPrefix(key, fmt.Sprintf("%s:", kind)) {
+			return true
+		}
+	}
+	return false
+}
@@ -69,6 +69,7 @@ type dynamicValue struct {
 type DynamicValue interface {
 	Get(value string, request *vo.EndpointRequest, history *aggregate.History) (string, []error)
 	GetAsSliceOfString(value string, request *vo.EndpointRequest, history *aggregate.History) ([]string, []error)
+	GetTyped(value string, request *vo.EndpointRequest, history *aggregate.History) (any, []error)
 	EvalBool(exprs []string, request *vo.EndpointRequest, history *aggregate.History) (bool, []error)
 	EvalFunc(expr string, request *vo.EndpointRequest, history *aggregate.History) (any, []error)
 	EvalGuards(onlyIf, ignoreIf []string, request *vo.EndpointRequest, history *aggregate.History) (bool, string, []error)
@@ -119,6 +120,23 @@ func (d dynamicValue) GetAsSliceOfString(value string, request *vo.EndpointReque
 	return []string{newValue}, errs
 }
 
+// GetTyped keeps the JSON type of the value when it is a single value expression, so "#request.body.limit" holding 10
+// returns the number, while the header, query and param values remain strings. Any other value is resolved by Get.
+func (d dynamicValue) GetTyped(value string, request *vo.EndpointRequest, history *aggregate.History) (any, []error) {
+	trimmed := strings.TrimSpace(value)
+	if words := d.findAllBySyntax(trimmed); checker.IsLengthNotEquals(words, 1) || checker.NotEquals(words[0], trimmed) {
+		return d.Get(value, request, history)
+	}
+
+	result, err := d.getJSONValueBySyntax(trimmed, request, history)
+	if errors.Is(err, domain.ErrDynamicValueNotFound) {
+		return value, nil
+	} else if checker.NonNil(err) {
+		return nil, errors.InheritAsSlicef(err, "dynamic-value failed: op=get-json-value-by-syntax word=%s", trimmed)
+	}
+	return result.Interface(), nil
+}
+
 func (d dynamicValue) EvalBool(exprs []string, request *vo.EndpointRequest, history *aggregate.History) (bool, []error) {
 	for _, expr := range exprs {
 		expr = strings.TrimSpace(expr)
@@ -549,6 +567,17 @@ func (d dynamicValue) scanBalancedParens(s string, openIdx int) int {
 }
 
 func (d dynamicValue) getValueBySyntax(word string, request *vo.EndpointRequest, history *aggregate.History) (string, error) {
+	result, err := d.getJSONValueBySyntax(word, request, history)
+	if checker.NonNil(err) {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// getJSONValueBySyntax returns the value found keeping its JSON type, the header, query and param values are strings
+// in the request map, the body and response values keep the type of their payload.
+func (d dynamicValue) getJSONValueBySyntax(word string, request *vo.EndpointRequest, history *aggregate.History) (
+	domain.JSONValue, error) {
 	// Operador de fallback/coalesce: tenta da esquerda para a direita
 	// Ex.: "#request.body.cpf || #request.query.cpf"
 	if strings.Contains(word, "||") {
@@ -566,28 +595,28 @@ func (d dynamicValue) getValueBySyntax(word string, request *vo.EndpointRequest,
 				lastNotFound = err
 				continue
 			} else if checker.NonNil(err) {
-				return "", errors.Inheritf(err, "dynamic-value failed: op=get-single-value-by-syntax part=%s full=%s", part, word)
+				return nil, errors.Inheritf(err, "dynamic-value failed: op=get-single-value-by-syntax part=%s full=%s", part, word)
 			}
 
 			return result, nil
 		}
 
 		if checker.NonNil(lastNotFound) {
-			return "", lastNotFound
+			return nil, lastNotFound
 		}
 
-		return "", errors.Newf("dynamic-value failed: invalid full=%s", word)
+		return nil, errors.Newf("dynamic-value failed: invalid full=%s", word)
 	}
 
 	return d.getSingleValueBySyntax(word, request, history)
 }
 
-func (d dynamicValue) getSingleValueBySyntax(word string, request *vo.EndpointRequest, history *aggregate.History) (string,
-	error) {
+func (d dynamicValue) getSingleValueBySyntax(word string, request *vo.EndpointRequest, history *aggregate.History) (
+	domain.JSONValue, error) {
 	cleanSintaxe := strings.ReplaceAll(word, "#", "")
 	dotSplit := strings.Split(cleanSintaxe, ".")
 	if checker.IsEmpty(dotSplit) {
-		return "", errors.Newf("dynamic-value failed: op=dot-split invalid token=%s", word)
+		return nil, errors.Newf("dynamic-value failed: op=dot-split invalid token=%s", word)
 	}
 
 	prefix := dotSplit[0]
@@ -596,49 +625,52 @@ func (d dynamicValue) getSingleValueBySyntax(word string, request *vo.EndpointRe
 	} else if checker.Contains(prefix, "responses") {
 		return d.getResponseValueByJSONPath(cleanSintaxe, history)
 	} else {
-		return "", errors.Newf("dynamic-value failed: op=get-first-dot-split invalid-prefix=%s token=%s", prefix, word)
+		return nil, errors.Newf("dynamic-value failed: op=get-first-dot-split invalid-prefix=%s token=%s", prefix, word)
 	}
 }
 
-func (d dynamicValue) getRequestValueByJSONPath(jsonPath string, request *vo.EndpointRequest) (string, error) {
+func (d dynamicValue) getRequestValueByJSONPath(jsonPath string, request *vo.EndpointRequest) (domain.JSONValue,
+	error) {
 	jsonPath = strings.Replace(jsonPath, "request.", "", 1)
 
 	jsonRequest, err := request.Map()
 	if checker.NonNil(err) {
-		return "", errors.Inheritf(err, "dynamic-value failed: op=request.map path=%s", jsonPath)
+		return nil, errors.Inheritf(err, "dynamic-value failed: op=request.map path=%s", jsonPath)
 	}
 
 	result := d.jsonPath.Get(jsonRequest, jsonPath)
 	if result.Exists() && checker.IsNotEmpty(result.String()) {
-		return result.String(), nil
+		return result, nil
 	}
 
-	return "", domain.NewErrDynamicValueNotFound(jsonPath)
+	return nil, domain.NewErrDynamicValueNotFound(jsonPath)
 }
 
-func (d dynamicValue) getResponseValueByJSONPath(jsonPath string, history *aggregate.History) (string, error) {
+func (d dynamicValue) getResponseValueByJSONPath(jsonPath string, history *aggregate.History) (domain.JSONValue,
+	error) {
 	if checker.IsNil(history) {
-		return "", errors.Newf("dynamic-value failed: op=history.nil path=%s", jsonPath)
+		return nil, errors.Newf("dynamic-value failed: op=history.nil path=%s", jsonPath)
 	} else if strings.HasPrefix(jsonPath, "responses[") {
 		return d.getResponseValueByBracketJSONPath(jsonPath, history)
 	} else if checker.Equals(jsonPath, "responses.ok") {
-		return converter.ToString(history.AllOK()), nil
+		return d.jsonPath.Parse(converter.ToString(history.AllOK())), nil
 	} else if checker.Equals(jsonPath, "responses.executed") {
-		return converter.ToString(history.AllExecuted()), nil
+		return d.jsonPath.Parse(converter.ToString(history.AllExecuted())), nil
 	}
 
-	return "", errors.Newf("dynamic-value failed: invalid syntax=%s", jsonPath)
+	return nil, errors.Newf("dynamic-value failed: invalid syntax=%s", jsonPath)
 }
 
-func (d dynamicValue) getResponseValueByBracketJSONPath(jsonPath string, history *aggregate.History) (string, error) {
+func (d dynamicValue) getResponseValueByBracketJSONPath(jsonPath string, history *aggregate.History) (
+	domain.JSONValue, error) {
 	closeIndex := strings.Index(jsonPath, "]")
 	if checker.IsLengthLessThan(closeIndex, 0) {
-		return "", errors.Newf("dynamic-value failed: invalid syntax=%s missing ']'", jsonPath)
+		return nil, errors.Newf("dynamic-value failed: invalid syntax=%s missing ']'", jsonPath)
 	}
 
 	key := strings.TrimSpace(jsonPath[len("responses["):closeIndex])
 	if checker.IsEmpty(key) {
-		return "", errors.Newf("dynamic-value failed: invalid syntax=%s empty key", jsonPath)
+		return nil, errors.Newf("dynamic-value failed: invalid syntax=%s empty key", jsonPath)
 	}
 
 	rest := strings.TrimPrefix(jsonPath[closeIndex+1:], ".")
@@ -649,10 +681,11 @@ func (d dynamicValue) getResponseValueByBracketJSONPath(jsonPath string, history
 	return d.getResponseValueByID(key, rest, history)
 }
 
-func (d dynamicValue) getResponseValueByIndex(index string, rest string, history *aggregate.History) (string, error) {
+func (d dynamicValue) getResponseValueByIndex(index string, rest string, history *aggregate.History) (
+	domain.JSONValue, error) {
 	jsonResponses, err := history.ResponsesMapByIndex()
 	if checker.NonNil(err) {
-		return "", errors.Inheritf(err, "dynamic-value failed: op=history.map index=%s rest=%s", index, rest)
+		return nil, errors.Inheritf(err, "dynamic-value failed: op=history.map index=%s rest=%s", index, rest)
 	}
 
 	lookupPath := index
@@ -662,16 +695,17 @@ func (d dynamicValue) getResponseValueByIndex(index string, rest string, history
 
 	result := d.jsonPath.Get(jsonResponses, lookupPath)
 	if result.Exists() && checker.IsNotEmpty(result.String()) {
-		return result.String(), nil
+		return result, nil
 	}
 
-	return "", domain.NewErrDynamicValueNotFound("responses[" + index + "]." + rest)
+	return nil, domain.NewErrDynamicValueNotFound("responses[" + index + "]." + rest)
 }
 
-func (d dynamicValue) getResponseValueByID(id string, rest string, history *aggregate.History) (string, error) {
+func (d dynamicValue) getResponseValueByID(id string, rest string, history *aggregate.History) (domain.JSONValue,
+	error) {
 	jsonResponses, err := history.ResponsesMapByID()
 	if checker.NonNil(err) {
-		return "", errors.Inheritf(err, "dynamic-value failed: op=history.map id=%s rest=%s", id, rest)
+		return nil, errors.Inheritf(err, "dynamic-value failed: op=history.map id=%s rest=%s", id, rest)
 	}
 
 	lookupPath := id
@@ -681,10 +715,10 @@ func (d dynamicValue) getResponseValueByID(id string, rest string, history *aggr
 
 	result := d.jsonPath.Get(jsonResponses, lookupPath)
 	if result.Exists() && checker.IsNotEmpty(result.String()) {
-		return result.String(), nil
+		return result, nil
 	}
 
-	return "", domain.NewErrDynamicValueNotFound("responses[" + id + "]." + rest)
+	return nil, domain.NewErrDynamicValueNotFound("responses[" + id + "]." + rest)
 }
 
 func (d dynamicValue) evalTernaryExpr(
@@ -17,34 +17,88 @@
 package service
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/jellydator/ttlcache/v2"
 	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
+	"github.com/tech4works/errors"
 	"github.com/tech4works/gopen-gateway/internal/app"
 	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
 	timerate "golang.org/x/time/rate"
 )
 
 type limiter struct {
-	keys  map[string]*timerate.Limiter
-	mutex *sync.RWMutex
+	dynamicValueService DynamicValue
+	rateLimiters        *ttlcache.Cache
+	concurrencies       map[string]*concurrency
+	mutex               *sync.RWMutex
+	rateStore           domain.RateStore
 }
 
+// concurrency holds the in-flight slots of an endpoint and its latest latencies, used by the adaptive shedding.
+type concurrency struct {
+	slots      chan struct{}
+	waiting    atomic.Int64
+	mutex      sync.Mutex
+	latencies  []latencySample
+	next       int
+	p99        time.Duration
+	computedAt time.Time
+}
+
+type latencySample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// globalConcurrencyKey identifies the in-flight slots shared by every endpoint, the endpoints are keyed by method and
+// path, so it never collides with them.
+const globalConcurrencyKey = "*"
+
+const (
+	latencySamples       = 1024
+	latencyComputeEvery  = time.Second
+	latencyPercentileP99 = 0.99
+)
+
 type Limiter interface {
 	AllowSize(config *vo.LimiterSizeConfig, request *vo.EndpointRequest) error
-	AllowRate(config *vo.LimiterRateConfig, request *vo.EndpointRequest) error
+	AllowRate(ctx context.Context, configs []*vo.LimiterRateConfig, request *vo.EndpointRequest) (
+		[]*vo.LimiterRateStatus, error)
+	BuildRateMetadata(statuses []*vo.LimiterRateStatus) vo.Metadata
+	AcquireConcurrency(ctx context.Context, config *vo.LimiterConfig, endpoint string) (func(), error)
+	Close() error
 }
 
-func NewLimiter() Limiter {
+// NewLimiter creates the limiter service, the rate store is optional and only used by the distributed rate configs.
+func NewLimiter(dynamicValueService DynamicValue, rateStore domain.RateStore) Limiter {
 	return &limiter{
-		keys:  map[string]*timerate.Limiter{},
-		mutex: &sync.RWMutex{},
+		dynamicValueService: dynamicValueService,
+		rateLimiters:        ttlcache.NewCache(),
+		concurrencies:       map[string]*concurrency{},
+		mutex:               &sync.RWMutex{},
+		rateStore:           rateStore,
 	}
 }
 
+// Close stops the expiration of the in memory rate buckets.
+func (s *limiter) Close() error {
+	return s.rateLimiters.Close()
+}
+
 func (s *limiter) AllowSize(config *vo.LimiterSizeConfig, request *vo.EndpointRequest) error {
 	if checker.IsNil(config) {
 		return nil
@@ -72,25 +126,315 @@ func (s *limiter) AllowSize(config *vo.LimiterSizeConfig, request *vo.EndpointRe
 	return nil
 }
 
-func (s *limiter) AllowRate(config *vo.LimiterRateConfig, request *vo.EndpointRequest) error {
-	if checker.IsNil(config) {
+// AllowRate checks every rate rule in order, the request is rejected by the first rule that has no capacity left. The
+// status of each counted rule is returned so that it can be exposed to the client.
+func (s *limiter) AllowRate(ctx context.Context, configs []*vo.LimiterRateConfig, request *vo.EndpointRequest) (
+	[]*vo.LimiterRateStatus, error) {
+	var statuses []*vo.LimiterRateStatus
+	for _, config := range configs {
+		status, err := s.allowRate(ctx, config, request)
+		if checker.NonNil(err) {
+			return statuses, err
+		} else if checker.IsNil(status) {
+			continue
+		}
+
+		statuses = append(statuses, status)
+		if !status.Allowed() {
+			return statuses, domain.NewErrLimiterTooManyRequests(config.Capacity(), config.EveryTime())
+		}
+	}
+	return statuses, nil
+}
+
+// BuildRateMetadata builds the RateLimit headers from the most restrictive rule, listing every rule in the policy, and
+// the Retry-After header when the request was rejected.
+func (s *limiter) BuildRateMetadata(statuses []*vo.LimiterRateStatus) vo.Metadata {
+	if checker.IsEmpty(statuses) {
+		return vo.NewEmptyMetadata()
+	}
+
+	current := statuses[len(statuses)-1]
+	policies := make([]string, len(statuses))
+	for i, status := range statuses {
+		if current.Allowed() && checker.IsLessThan(status.Remaining(), current.Remaining()) {
+			current = status
+		}
+		policies[i] = fmt.Sprintf("%d;w=%d", status.Limit(), ceilSeconds(status.Window()))
+	}
+
+	mapMetadata := map[string][]string{
+		app.RateLimitLimit:     {converter.ToString(current.Limit())},
+		app.RateLimitRemaining: {converter.ToString(current.Remaining())},
+		app.RateLimitReset:     {converter.ToString(ceilSeconds(current.Reset()))},
+		app.RateLimitPolicy:    {strings.Join(policies, ", ")},
+	}
+	if !current.Allowed() {
+		mapMetadata[app.RetryAfter] = []string{converter.ToString(max(ceilSeconds(current.RetryAfter()), 1))}
+	}
+
+	return vo.NewMetadata(mapMetadata)
+}
+
+// AcquireConcurrency takes an in-flight slot of the gateway and then one of the endpoint, waiting in the bounded queue
+// when all of them are busy, and sheds the request when the adaptive thresholds are crossed. The returned func releases
+// both slots and must be called once the request is completed.
+func (s *limiter) AcquireConcurrency(ctx context.Context, config *vo.LimiterConfig, endpoint string) (func(), error) {
+	releaseGlobal := func() {}
+	if config.HasGlobalConcurrency() {
+		release, err := s.acquireConcurrency(ctx, config.GlobalConcurrency(), globalConcurrencyKey)
+		if checker.NonNil(err) {
+			return nil, err
+		}
+		releaseGlobal = release
+	}
+
+	if !config.HasConcurrency() {
+		return releaseGlobal, nil
+	}
+
+	releaseEndpoint, err := s.acquireConcurrency(ctx, config.Concurrency(), endpoint)
+	if checker.NonNil(err) {
+		releaseGlobal()
+		return nil, err
+	}
+
+	return func() {
+		releaseEndpoint()
+		releaseGlobal()
+	}, nil
+}
+
+func (s *limiter) acquireConcurrency(ctx context.Context, config *vo.LimiterConcurrencyConfig, key string) (
+	func(), error) {
+	state := s.getConcurrency(config, key)
+
+	if config.HasAdaptive() {
+		err := s.evalShedding(config.Adaptive(), state)
+		if checker.NonNil(err) {
+			return nil, err
+		}
+	}
+
+	if config.HasMaxInFlight() {
+		err := s.acquireSlot(ctx, config, state)
+		if checker.NonNil(err) {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	return func() {
+		if config.HasMaxInFlight() {
+			<-state.slots
+		}
+		state.record(start, time.Since(start))
+	}, nil
+}
+
+// allowRate returns nil status when the rule is not applied to the request.
+func (s *limiter) allowRate(ctx context.Context, config *vo.LimiterRateConfig, request *vo.EndpointRequest) (
+	*vo.LimiterRateStatus, error) {
+	errs := s.dynamicValueService.EvalGuardsWithErr(config.OnlyIf(), config.IgnoreIf(), request, nil)
+	if errors.Only(errs, domain.ErrEvalGuards) {
+		return nil, nil
+	} else if checker.IsNotEmpty(errs) {
+		return nil, errors.NewByChainf(errs, "failed to evaluate guard for limiter rate")
+	}
+
+	key, err := s.buildRateKey(config, request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	if config.HasDistributed() {
+		return s.allowDistributedRate(ctx, config, key), nil
+	}
+	return s.allowLocalRate(config, key), nil
+}
+
+// buildRateKey identifies the bucket by the rule and by the value of its key expression, when the rule has no key or
+// the expression does not resolve a value, the bucket belongs to the consumer or to the client IP.
+func (s *limiter) buildRateKey(config *vo.LimiterRateConfig, request *vo.EndpointRequest) (string, error) {
+	rule := fmt.Sprintf("%d/%s", config.Capacity(), config.Every())
+
+	if config.HasKey() {
+		value, errs := s.dynamicValueService.Get(config.Key(), request, nil)
+		if checker.IsNotEmpty(errs) {
+			return "", errors.NewByChainf(errs, "limiter failed: op=build-key")
+		} else if checker.IsNotEmpty(value) && checker.NotEquals(value, config.Key()) {
+			return fmt.Sprintf("%s:key:%s=%s", rule, config.Key(), value), nil
+		}
+	}
+
+	if request.HasConsumer() {
+		return fmt.Sprintf("%s:consumer:%s", rule, request.Consumer().Name()), nil
+	}
+	return fmt.Sprintf("%s:ip:%s", rule, request.ClientIP()), nil
+}
+
+// allowDistributedRate shares the bucket between every gateway instance through the rate store, when the store is
+// unreachable the configured failure policy decides the request.
+func (s *limiter) allowDistributedRate(ctx context.Context, config *vo.LimiterRateConfig, key string,
+) *vo.LimiterRateStatus {
+	distributed := config.Distributed()
+
+	if checker.NonNil(s.rateStore) {
+		storeCtx, cancel := context.WithTimeout(ctx, distributed.Timeout())
+		defer cancel()
+
+		status, err := s.rateStore.Allow(storeCtx, distributed.Algorithm(), key, config.Capacity(),
+			config.EveryTime())
+		if checker.IsNil(err) {
+			return status
+		}
+	}
+
+	switch distributed.OnFailure() {
+	case enum.LimiterOnFailureOpen:
 		return nil
+	case enum.LimiterOnFailureClosed:
+		return vo.NewLimiterRateStatus(false, config.Capacity(), 0, rateWindow(config), config.EveryTime(),
+			config.EveryTime())
+	default:
+		return s.allowLocalRate(config, key)
 	}
+}
 
+// allowLocalRate keeps each bucket in memory while it is used, a bucket idle for the time it takes to refill is full
+// again, so it expires and is created again on the next request.
+func (s *limiter) allowLocalRate(config *vo.LimiterRateConfig, key string) *vo.LimiterRateStatus {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	clientIP := request.Metadata().GetFirst(app.XForwardedFor)
+	var rateLimiter *timerate.Limiter
+	if value, err := s.rateLimiters.Get(key); checker.IsNil(err) {
+		rateLimiter = value.(*timerate.Limiter)
+	} else {
+		rateLimiter = timerate.NewLimiter(timerate.Every(config.EveryTime()), config.Capacity())
+		_ = s.rateLimiters.SetWithTTL(key, rateLimiter, config.EveryTime()*time.Duration(config.Capacity()))
+	}
+
+	now := time.Now()
+	allowed := rateLimiter.AllowN(now, 1)
+	tokens := rateLimiter.TokensAt(now)
+
+	var retryAfter time.Duration
+	if !allowed {
+		retryAfter = time.Duration((1 - tokens) * float64(config.EveryTime()))
+	}
+	reset := time.Duration((float64(config.Capacity()) - tokens) * float64(config.EveryTime()))
 
-	rateLimiter, exists := s.keys[clientIP]
+	return vo.NewLimiterRateStatus(allowed, config.Capacity(), int(math.Floor(tokens)), rateWindow(config), reset,
+		retryAfter)
+}
+
+// rateWindow returns the period in which the capacity is fully granted, the token bucket takes every to recover each
+// request while the sliding window grants the whole capacity at every.
+func rateWindow(config *vo.LimiterRateConfig) time.Duration {
+	if config.HasDistributed() && checker.Equals(config.Distributed().Algorithm(), enum.LimiterAlgorithmSlidingWindow) {
+		return config.EveryTime()
+	}
+	return config.EveryTime() * time.Duration(config.Capacity())
+}
+
+func (s *limiter) getConcurrency(config *vo.LimiterConcurrencyConfig, key string) *concurrency {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, exists := s.concurrencies[key]
 	if !exists {
-		rateLimiter = timerate.NewLimiter(timerate.Every(config.EveryTime()), config.Capacity())
-		s.keys[clientIP] = rateLimiter
+		state = &concurrency{
+			latencies: make([]latencySample, latencySamples),
+		}
+		if config.HasMaxInFlight() {
+			state.slots = make(chan struct{}, config.MaxInFlight())
+		}
+		s.concurrencies[key] = state
 	}
+	return state
+}
 
-	if !rateLimiter.Allow() {
-		return domain.NewErrLimiterTooManyRequests(config.Capacity(), config.EveryTime())
+func (s *limiter) acquireSlot(ctx context.Context, config *vo.LimiterConcurrencyConfig, state *concurrency) error {
+	select {
+	case state.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if checker.IsGreaterThan(state.waiting.Add(1), int64(config.MaxQueue())) {
+		state.waiting.Add(-1)
+		return domain.NewErrLimiterConcurrencyExceeded(config.MaxInFlight(), config.MaxQueue(), "queue full")
+	}
+	defer state.waiting.Add(-1)
+
+	timer := time.NewTimer(config.QueueTimeout())
+	defer timer.Stop()
+
+	select {
+	case state.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return domain.NewErrLimiterConcurrencyExceeded(config.MaxInFlight(), config.MaxQueue(), "queue timeout")
+	case <-ctx.Done():
+		return domain.NewErrLimiterConcurrencyExceeded(config.MaxInFlight(), config.MaxQueue(), "queue cancelled")
+	}
+}
+
+func (s *limiter) evalShedding(config *vo.LimiterConcurrencyAdaptiveConfig, state *concurrency) error {
+	if config.HasMaxGoroutines() {
+		goroutines := runtime.NumGoroutine()
+		if checker.IsGreaterThan(goroutines, config.MaxGoroutines()) {
+			return domain.NewErrLimiterLoadShed(fmt.Sprintf("goroutines=%d", goroutines))
+		}
+	}
+
+	if config.HasMaxLatency() {
+		p99 := state.latencyP99(config.Window())
+		if checker.IsGreaterThan(p99, config.MaxLatency()) {
+			return domain.NewErrLimiterLoadShed(fmt.Sprintf("p99=%s", p99))
+		}
 	}
 
 	return nil
 }
+
+func (c *concurrency) record(at time.Time, duration time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.latencies[c.next] = latencySample{at: at, duration: duration}
+	c.next = (c.next + 1) % len(c.latencies)
+}
+
+// latencyP99 computes the p99 of the samples inside the window at most once per second, so that the requests shed
+// while the latency is high let the old samples leave the window and the endpoint recovers.
+func (c *concurrency) latencyP99(window time.Duration) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if checker.IsLessThan(now.Sub(c.computedAt), latencyComputeEvery) {
+		return c.p99
+	}
+
+	var durations []time.Duration
+	for _, sample := range c.latencies {
+		if !sample.at.IsZero() && checker.IsLessThanOrEqual(now.Sub(sample.at), window) {
+			durations = append(durations, sample.duration)
+		}
+	}
+
+	c.p99 = 0
+	if checker.IsNotEmpty(durations) {
+		slices.Sort(durations)
+		c.p99 = durations[int(math.Ceil(float64(len(durations))*latencyPercentileP99))-1]
+	}
+	c.computedAt = now
+
+	return c.p99
+}
+
+func ceilSeconds(duration time.Duration) int64 {
+	return int64(math.Ceil(duration.Seconds()))
+}
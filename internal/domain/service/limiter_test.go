@@ -0,0 +1,174 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/jsonpath"
+)
+
+type limiterTestRateStore struct {
+	status *vo.LimiterRateStatus
+	err    error
+}
+
+func (l limiterTestRateStore) Allow(context.Context, enum.LimiterAlgorithm, string, int, time.Duration) (
+	*vo.LimiterRateStatus, error) {
+	return l.status, l.err
+}
+
+func newLimiterTestRequest(clientIP string, header map[string][]string) *vo.EndpointRequest {
+	return vo.NewHTTPEndpointRequest("id", "trace", clientIP, "/users", vo.NewURLPath("/users", nil),
+		vo.NewEmptyQuery(), "GET", vo.NewMetadata(header), nil, nil)
+}
+
+func newLimiterTestRate(key string, capacity int, distributed *vo.LimiterRateDistributedConfig,
+) *vo.LimiterRateConfig {
+	return vo.NewLimiterRateConfig(nil, nil, key, vo.NewDuration(time.Minute), capacity, distributed)
+}
+
+func TestLimiterAllowRateLocal(t *testing.T) {
+	limiter := NewLimiter(NewDynamicValue(jsonpath.New()), nil)
+	defer limiter.Close()
+
+	configs := []*vo.LimiterRateConfig{newLimiterTestRate("", 2, nil)}
+	for i := 0; i < 2; i++ {
+		statuses, err := limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.1", nil))
+		if err != nil {
+			t.Fatalf("AllowRate() request %d error = %v, want nil", i+1, err)
+		} else if statuses[0].Remaining() != 1-i {
+			t.Errorf("AllowRate() request %d remaining = %d, want %d", i+1, statuses[0].Remaining(), 1-i)
+		}
+	}
+
+	statuses, err := limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.1", nil))
+	if !errors.Is(err, domain.ErrLimiterTooManyRequests) {
+		t.Fatalf("AllowRate() error = %v, want too many requests", err)
+	} else if statuses[0].Allowed() || statuses[0].RetryAfter() <= 0 {
+		t.Errorf("AllowRate() status = %+v, want rejected with retry after", statuses[0])
+	}
+
+	_, err = limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.2", nil))
+	if err != nil {
+		t.Errorf("AllowRate() other client error = %v, want nil", err)
+	}
+}
+
+func TestLimiterAllowRateMultipleRules(t *testing.T) {
+	limiter := NewLimiter(NewDynamicValue(jsonpath.New()), nil)
+	defer limiter.Close()
+
+	configs := []*vo.LimiterRateConfig{newLimiterTestRate("", 5, nil), newLimiterTestRate("", 1, nil)}
+
+	statuses, err := limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.1", nil))
+	if err != nil || len(statuses) != 2 {
+		t.Fatalf("AllowRate() = %d statuses, %v, want 2 statuses and nil", len(statuses), err)
+	}
+
+	statuses, err = limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.1", nil))
+	if !errors.Is(err, domain.ErrLimiterTooManyRequests) {
+		t.Fatalf("AllowRate() error = %v, want too many requests", err)
+	} else if !statuses[0].Allowed() || statuses[1].Allowed() {
+		t.Errorf("AllowRate() statuses allowed = %v, %v, want true, false", statuses[0].Allowed(),
+			statuses[1].Allowed())
+	}
+
+	metadata := limiter.BuildRateMetadata(statuses)
+	if got := metadata.GetFirst(app.RateLimitLimit); got != "1" {
+		t.Errorf("BuildRateMetadata() %s = %q, want %q", app.RateLimitLimit, got, "1")
+	} else if got = metadata.GetFirst(app.RateLimitPolicy); got != "5;w=300, 1;w=60" {
+		t.Errorf("BuildRateMetadata() %s = %q, want %q", app.RateLimitPolicy, got, "5;w=300, 1;w=60")
+	} else if metadata.GetFirst(app.RetryAfter) == "" {
+		t.Errorf("BuildRateMetadata() %s is empty", app.RetryAfter)
+	}
+}
+
+func TestLimiterBuildRateKey(t *testing.T) {
+	limiter := &limiter{dynamicValueService: NewDynamicValue(jsonpath.New())}
+	config := newLimiterTestRate("#request.header.X-Api-Key.0", 10, nil)
+
+	tests := []struct {
+		name    string
+		request *vo.EndpointRequest
+		want    string
+	}{
+		{
+			"key resolved",
+			newLimiterTestRequest("10.0.0.1", map[string][]string{"X-Api-Key": {"abc"}}),
+			"10/1m0s:key:#request.header.X-Api-Key.0=abc",
+		},
+		{
+			"key not resolved",
+			newLimiterTestRequest("10.0.0.1", nil),
+			"10/1m0s:ip:10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := limiter.buildRateKey(config, tt.request)
+			if err != nil {
+				t.Fatalf("buildRateKey() error = %v, want nil", err)
+			} else if got != tt.want {
+				t.Errorf("buildRateKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterAllowRateDistributed(t *testing.T) {
+	rejected := vo.NewLimiterRateStatus(false, 1, 0, time.Minute, time.Minute, time.Minute)
+	unreachable := limiterTestRateStore{err: errors.New("connection refused")}
+
+	tests := []struct {
+		name        string
+		store       domain.RateStore
+		onFailure   enum.LimiterOnFailure
+		wantAllowed []bool
+	}{
+		{"store decision", limiterTestRateStore{status: rejected}, enum.LimiterOnFailureOpen, []bool{false}},
+		{"store unreachable open", unreachable, enum.LimiterOnFailureOpen, []bool{true, true}},
+		{"store unreachable closed", unreachable, enum.LimiterOnFailureClosed, []bool{false}},
+		{"store unreachable local", unreachable, enum.LimiterOnFailureLocal, []bool{true, false}},
+		{"store not configured", nil, enum.LimiterOnFailureLocal, []bool{true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewLimiter(NewDynamicValue(jsonpath.New()), tt.store)
+			defer limiter.Close()
+
+			distributed := vo.NewLimiterRateDistributedConfig(enum.LimiterAlgorithmTokenBucket, tt.onFailure,
+				vo.NewDuration(time.Second))
+			configs := []*vo.LimiterRateConfig{newLimiterTestRate("", 1, distributed)}
+
+			for i, wantAllowed := range tt.wantAllowed {
+				_, err := limiter.AllowRate(context.Background(), configs, newLimiterTestRequest("10.0.0.1", nil))
+				if allowed := err == nil; allowed != wantAllowed {
+					t.Errorf("AllowRate() request %d error = %v, want allowed %v", i+1, err, wantAllowed)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,6 +40,7 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
 	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/websocket"
 )
 
 type Context struct {
@@ -52,7 +54,9 @@ type Context struct {
 }
 
 type engine struct {
-	http *gin.Context
+	http     *gin.Context
+	grpc     *grpcEngine
+	upgraded bool
 }
 
 func newHTTPContext(gin *gin.Context, gopen *vo.GopenConfig, endpoint *vo.EndpointConfig) app.Context {
@@ -129,12 +133,47 @@ func buildHTTPRequest(gin *gin.Context, endpoint *vo.EndpointConfig) *vo.Endpoin
 		panic(err)
 	}
 
+	requestTLS := buildRequestTLS(gin.Request.TLS)
+
+	if endpoint.IsWebSocket() {
+		return vo.NewWebSocketEndpointRequest(requestID, traceID, clientIP, url, path, query, header, requestTLS)
+	}
+
 	body := vo.NewPayload(gin.GetHeader(app.ContentType), gin.GetHeader(app.ContentEncoding), bytes.NewBuffer(bodyBytes))
 
-	return vo.NewHTTPEndpointRequest(requestID, traceID, clientIP, url, path, query, gin.Request.Method, header, body)
+	return vo.NewHTTPEndpointRequest(requestID, traceID, clientIP, url, path, query, gin.Request.Method, header, body,
+		requestTLS)
+}
+
+func buildRequestTLS(state *tls.ConnectionState) *vo.RequestTLS {
+	if checker.IsNil(state) {
+		return nil
+	}
+
+	requestTLS := vo.NewRequestTLS(tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite),
+		state.ServerName)
+	if checker.IsEmpty(state.PeerCertificates) {
+		return requestTLS
+	}
+
+	peer := state.PeerCertificates[0]
+
+	var ips, uris []string
+	for _, ip := range peer.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	for _, uri := range peer.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	return requestTLS.WithPeer(checker.IsNotEmpty(state.VerifiedChains), peer.Subject.String(), peer.Subject.CommonName,
+		peer.Issuer.String(), peer.SerialNumber.String(), peer.DNSNames, peer.EmailAddresses, ips, uris)
 }
 
 func (c *Context) Context() context.Context {
+	if c.engine.isGRPC() {
+		return c.engine.grpc.ctx
+	}
 	return c.engine.http.Request.Context()
 }
 
@@ -143,18 +182,33 @@ func (c *Context) Done() <-chan struct{} {
 }
 
 func (c *Context) WithContext(ctx context.Context) {
+	if c.engine.isGRPC() {
+		c.engine.grpc.ctx = ctx
+		return
+	}
 	c.engine.http.Request = c.engine.http.Request.WithContext(ctx)
 }
 
 func (c *Context) Next() {
+	if c.engine.isGRPC() {
+		c.engine.grpc.next(c)
+		return
+	}
 	c.engine.http.Next()
 }
 
 func (c *Context) Abort() {
+	if c.engine.isGRPC() {
+		c.engine.grpc.abort()
+		return
+	}
 	c.engine.http.Abort()
 }
 
 func (c *Context) IsAborted() bool {
+	if c.engine.isGRPC() {
+		return c.engine.grpc.isAborted()
+	}
 	return c.engine.http.IsAborted()
 }
 
@@ -174,6 +228,10 @@ func (c *Context) Request() *vo.EndpointRequest {
 	return c.request
 }
 
+func (c *Context) WithRequest(request *vo.EndpointRequest) {
+	c.request = request
+}
+
 func (c *Context) Response() *vo.EndpointResponse {
 	return c.response
 }
@@ -182,7 +240,11 @@ func (c *Context) Write(response *vo.EndpointResponse) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.writeHTTPResponse(response)
+	if c.engine.isGRPC() {
+		c.writeGRPCResponse(response)
+	} else {
+		c.writeHTTPResponse(response)
+	}
 }
 
 func (c *Context) WriteError(status enum.ResponseStatus, err error) {
@@ -216,16 +278,39 @@ func (c *Context) WriteJSON(status enum.ResponseStatus, a any) {
 }
 
 func (c *Context) WriteMetadata(metadata vo.Metadata) {
+	if c.engine.isGRPC() {
+		c.engine.grpc.writeHeader(metadata)
+		return
+	}
 	for _, key := range metadata.Keys() {
 		c.engine.http.Header(key, metadata.Get(key))
 	}
 }
 
+func (c *Context) UpgradeWebSocket(subprotocol string) (app.WebSocketConn, error) {
+	if c.engine.isGRPC() {
+		return nil, errors.New("websocket upgrade is not supported over gRPC")
+	}
+
+	conn, err := websocket.Upgrade(c.engine.http.Writer, c.engine.http.Request, subprotocol)
+	if checker.IsNil(err) {
+		c.engine.upgraded = true
+	}
+	return conn, err
+}
+
 func (c *Context) writeHTTPResponse(response *vo.EndpointResponse) {
 	if c.IsAborted() {
 		return
 	}
 
+	// The connection was hijacked by the WebSocket upgrade, the response is kept only for logs.
+	if c.engine.upgraded {
+		c.Abort()
+		c.response = response
+		return
+	}
+
 	var statusCode int
 	if response.Status().HasRaw() && converter.CouldBeInt(response.Status().Raw()) {
 		statusCode = converter.ToInt(response.Status().Raw())
@@ -246,6 +331,11 @@ func (c *Context) writeHTTPResponse(response *vo.EndpointResponse) {
 
 	c.decorateHTTPTransportHeaders(response, statusCode)
 
+	if c.isHTTPNotModified(response, statusCode) {
+		statusCode = http.StatusNotModified
+		rawBodyBytes = nil
+	}
+
 	if checker.IsNotEmpty(rawBodyBytes) {
 		c.engine.http.Data(statusCode, contentType.String(), rawBodyBytes)
 	} else {
@@ -256,6 +346,46 @@ func (c *Context) writeHTTPResponse(response *vo.EndpointResponse) {
 	c.response = response
 }
 
+// isHTTPNotModified returns true when the conditional request matches the validators of the successful response, only
+// on endpoints caching in the HTTP mode.
+func (c *Context) isHTTPNotModified(response *vo.EndpointResponse, statusCode int) bool {
+	if !c.endpoint.HasCache() || !c.endpoint.Cache().HasHTTP() || checker.NotEquals(statusCode, http.StatusOK) {
+		return false
+	} else if method := c.engine.http.Request.Method; checker.NotEquals(method, http.MethodGet) &&
+		checker.NotEquals(method, http.MethodHead) {
+		return false
+	}
+	return isNotModified(c.engine.http.Request.Header, response.Metadata())
+}
+
+// isNotModified compares the conditional headers of the request with the validators of the response metadata,
+// If-None-Match takes precedence over If-Modified-Since.
+func isNotModified(header http.Header, metadata vo.Metadata) bool {
+	if ifNoneMatch := header.Get("If-None-Match"); checker.IsNotEmpty(ifNoneMatch) {
+		etag := strings.TrimPrefix(metadata.GetFirst("ETag"), "W/")
+		if checker.IsEmpty(etag) {
+			return false
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if checker.Equals(candidate, "*") || checker.Equals(strings.TrimPrefix(candidate, "W/"), etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(header.Get("If-Modified-Since"))
+	if checker.NonNil(err) {
+		return false
+	}
+	lastModified, err := http.ParseTime(metadata.GetFirst("Last-Modified"))
+	if checker.NonNil(err) {
+		return false
+	}
+	return !lastModified.After(ifModifiedSince)
+}
+
 func (c *Context) parseResponseStatusToHTTPStatusCode(responseStatus enum.ResponseStatus) int {
 	switch responseStatus {
 	case enum.ResponseStatusOK:
@@ -403,6 +533,7 @@ func (c *Context) decorateHTTPTransportHeaders(response *vo.EndpointResponse, st
 		c.engine.http.Header(app.XGopenDeduplicationIDDegraded, converter.ToString(degradation.Has(enum.DegradationKindDeduplicationID)))
 		c.engine.http.Header(app.XGopenGroupIDDegraded, converter.ToString(degradation.Has(enum.DegradationKindGroupID)))
 		c.engine.http.Header(app.XGopenAttributeDegraded, converter.ToString(degradation.Has(enum.DegradationKindAttributes)))
+		c.engine.http.Header(app.XGopenRoutingKeyDegraded, converter.ToString(degradation.Has(enum.DegradationKindRoutingKey)))
 
 		backendsDegraded := response.Execution().Degradations()
 		if checker.IsNotEmpty(backendsDegraded) {
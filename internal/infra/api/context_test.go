@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+func TestIsNotModified(t *testing.T) {
+	metadata := vo.NewMetadata(map[string][]string{
+		"ETag":          {`"abc"`},
+		"Last-Modified": {"Wed, 21 Oct 2026 07:28:00 GMT"},
+	})
+
+	tests := []struct {
+		name     string
+		header   http.Header
+		metadata vo.Metadata
+		want     bool
+	}{
+		{"no conditional", http.Header{}, metadata, false},
+		{"if-none-match matches", http.Header{"If-None-Match": {`"abc"`}}, metadata, true},
+		{"if-none-match weak", http.Header{"If-None-Match": {`W/"abc"`}}, metadata, true},
+		{"if-none-match list", http.Header{"If-None-Match": {`"xyz", "abc"`}}, metadata, true},
+		{"if-none-match any", http.Header{"If-None-Match": {"*"}}, metadata, true},
+		{"if-none-match differs", http.Header{"If-None-Match": {`"xyz"`}}, metadata, false},
+		{"if-none-match without etag", http.Header{"If-None-Match": {"*"}}, vo.NewEmptyMetadata(), false},
+		{
+			"if-none-match takes precedence",
+			http.Header{"If-None-Match": {`"xyz"`}, "If-Modified-Since": {"Thu, 22 Oct 2026 07:28:00 GMT"}},
+			metadata,
+			false,
+		},
+		{"if-modified-since equal", http.Header{"If-Modified-Since": {"Wed, 21 Oct 2026 07:28:00 GMT"}}, metadata, true},
+		{"if-modified-since after", http.Header{"If-Modified-Since": {"Thu, 22 Oct 2026 07:28:00 GMT"}}, metadata, true},
+		{"if-modified-since before", http.Header{"If-Modified-Since": {"Tue, 20 Oct 2026 07:28:00 GMT"}}, metadata,
+			false},
+		{"if-modified-since malformed", http.Header{"If-Modified-Since": {"yesterday"}}, metadata, false},
+		{
+			"if-modified-since without last-modified",
+			http.Header{"If-Modified-Since": {"Wed, 21 Oct 2026 07:28:00 GMT"}},
+			vo.NewEmptyMetadata(),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotModified(tt.header, tt.metadata); got != tt.want {
+				t.Errorf("isNotModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
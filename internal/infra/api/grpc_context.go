@@ -0,0 +1,235 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const grpcAbortIndex int8 = math.MaxInt8 >> 1
+
+var grpcMarshalOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+var grpcUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// grpcEngine plays, for a gRPC call, the role gin.Context plays for HTTP: it holds the call context, walks the
+// handles chain and accumulates the metadata sent as the response header.
+type grpcEngine struct {
+	ctx     context.Context
+	index   int8
+	handles []app.HandlerFunc
+	header  metadata.MD
+}
+
+func newGRPCContext(
+	ctx context.Context,
+	gopen *vo.GopenConfig,
+	endpoint *vo.EndpointConfig,
+	handles []app.HandlerFunc,
+	input *dynamicpb.Message,
+) (*Context, error) {
+	request, err := buildGRPCRequest(ctx, endpoint, input)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+	return &Context{
+		startTime: time.Now(),
+		mutex:     &sync.RWMutex{},
+		engine: &engine{grpc: &grpcEngine{
+			ctx:     ctx,
+			index:   -1,
+			handles: handles,
+			header:  metadata.MD{},
+		}},
+		gopen:    gopen,
+		endpoint: endpoint,
+		request:  request,
+	}, nil
+}
+
+func buildGRPCRequest(ctx context.Context, endpoint *vo.EndpointConfig, input *dynamicpb.Message,
+) (*vo.EndpointRequest, error) {
+	header := buildGRPCHeader(ctx)
+
+	requestID := uuid.New().String()
+	if checker.NonNil(endpoint.RequestClient()) && checker.NonNil(endpoint.RequestClient().RequestID()) &&
+		endpoint.RequestClient().RequestID().HasHeaders() {
+		requestID = endpoint.RequestClient().RequestID().ResolveRequestID(header)
+	}
+
+	var traceID string
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	clientIP := resolveGRPCClientIP(ctx, header, endpoint.RequestClient())
+
+	payloadBytes, err := grpcMarshalOptions.Marshal(input)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "grpc request failed: op=marshal message=%s", input.Descriptor().FullName())
+	}
+	payload := vo.NewPayloadJSON(bytes.NewBuffer(payloadBytes))
+
+	return vo.NewGRPCEndpointRequest(requestID, traceID, clientIP, endpoint.GRPC().FullMethod(),
+		endpoint.GRPC().Method(), vo.NewMetadata(header), payload), nil
+}
+
+// buildGRPCHeader converts the incoming metadata to a header, dropping pseudo-headers and the protocol reserved
+// keys, which make no sense to the backends.
+func buildGRPCHeader(ctx context.Context) http.Header {
+	header := http.Header{}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") || checker.Equals(key, "te") ||
+			checker.Equals(key, "content-type") {
+			continue
+		}
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+
+	return header
+}
+
+func resolveGRPCClientIP(ctx context.Context, header http.Header, cfg *vo.RequestClientConfig) string {
+	var remoteIP string
+	if p, ok := peer.FromContext(ctx); ok && checker.NonNil(p.Addr) {
+		remoteIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(remoteIP); checker.IsNil(err) {
+			remoteIP = host
+		}
+	}
+
+	if checker.NonNil(cfg) && cfg.IP().HasHeaders() {
+		for _, key := range cfg.IP().Headers() {
+			if val := header.Get(key); checker.IsNotEmpty(val) {
+				if isIPTrusted(remoteIP, cfg.IP().TrustedProxies()) {
+					return val
+				}
+			}
+		}
+	}
+	return remoteIP
+}
+
+func (c *Context) writeGRPCResponse(response *vo.EndpointResponse) {
+	if c.IsAborted() {
+		return
+	}
+
+	c.engine.grpc.writeHeader(response.Metadata())
+
+	c.Abort()
+	c.response = response
+}
+
+// sendGRPCResponse encodes the endpoint response into the method output message, mapping the response status to
+// the equivalent gRPC code.
+func (c *Context) sendGRPCResponse(stream grpc.ServerStream, output protoreflect.MessageDescriptor) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	response := c.response
+	if checker.IsNil(response) {
+		return status.Error(codes.Internal, "endpoint finished without response")
+	}
+
+	if err := stream.SetHeader(c.engine.grpc.header); checker.NonNil(err) {
+		return err
+	}
+
+	code := c.parseResponseStatusToGRPCCode(response.Status().Value())
+	if checker.NotEquals(code, codes.OK) {
+		var msg string
+		if response.HasPayload() {
+			msg, _ = response.Payload().CompactString()
+		}
+		return status.Error(code, msg)
+	}
+
+	message := dynamicpb.NewMessage(output)
+	if response.HasPayload() {
+		payloadBytes, err := response.Payload().Bytes()
+		if checker.NonNil(err) {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err = grpcUnmarshalOptions.Unmarshal(payloadBytes, message); checker.NonNil(err) {
+			return status.Errorf(codes.Internal, "response payload is not compatible with %s: %s",
+				output.FullName(), err)
+		}
+	}
+
+	return stream.SendMsg(message)
+}
+
+func (e *engine) isGRPC() bool {
+	return checker.NonNil(e.grpc)
+}
+
+func (g *grpcEngine) next(ctx app.Context) {
+	g.index++
+	for g.index < int8(len(g.handles)) {
+		g.handles[g.index](ctx)
+		g.index++
+	}
+}
+
+func (g *grpcEngine) abort() {
+	g.index = grpcAbortIndex
+}
+
+func (g *grpcEngine) isAborted() bool {
+	return g.index >= grpcAbortIndex
+}
+
+// writeHeader skips the HTTP transport headers, they are controlled by the gRPC protocol itself.
+func (g *grpcEngine) writeHeader(header vo.Metadata) {
+	for _, key := range header.Keys() {
+		switch key {
+		case app.ContentType, app.ContentLength, app.ContentEncoding, "Connection", "Keep-Alive",
+			"Transfer-Encoding", "Date":
+			continue
+		}
+		g.header.Set(strings.ToLower(key), header.GetAll(key)...)
+	}
+}
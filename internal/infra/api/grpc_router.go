@@ -0,0 +1,101 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/descriptor"
+)
+
+type grpcRouter struct {
+	engine      *grpc.Server
+	descriptors *descriptor.Registry
+	routes      map[string]grpcRoute
+}
+
+type grpcRoute struct {
+	gopen    *vo.GopenConfig
+	endpoint *vo.EndpointConfig
+	method   protoreflect.MethodDescriptor
+	handles  []app.HandlerFunc
+}
+
+// NewGRPCRouter creates a router without compiled services, every call is received by the unknown service
+// handler and dispatched by its full method to the endpoint registered in Handle.
+func NewGRPCRouter(descriptors *descriptor.Registry) app.GRPCRouter {
+	r := &grpcRouter{
+		descriptors: descriptors,
+		routes:      map[string]grpcRoute{},
+	}
+	r.engine = grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnknownServiceHandler(r.handleStream),
+	)
+	return r
+}
+
+func (r *grpcRouter) Engine() *grpc.Server {
+	return r.engine
+}
+
+func (r *grpcRouter) Handle(gopen *vo.GopenConfig, endpoint *vo.EndpointConfig, handles ...app.HandlerFunc) {
+	method, err := r.descriptors.FindUnaryMethod(gopen.Server().GRPC().Descriptors(), endpoint.GRPC().Service(),
+		endpoint.GRPC().Method())
+	if checker.NonNil(err) {
+		panic(errors.Inheritf(err, "grpc router failed: op=handle method=%s", endpoint.GRPC().FullMethod()))
+	}
+
+	r.routes[endpoint.GRPC().FullMethod()] = grpcRoute{
+		gopen:    gopen,
+		endpoint: endpoint,
+		method:   method,
+		handles:  handles,
+	}
+}
+
+func (r *grpcRouter) handleStream(_ any, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+
+	route, ok := r.routes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method %s not implemented", fullMethod)
+	}
+
+	input := dynamicpb.NewMessage(route.method.Input())
+	if err := stream.RecvMsg(input); checker.NonNil(err) {
+		return err
+	}
+
+	ctx, err := newGRPCContext(stream.Context(), route.gopen, route.endpoint, route.handles, input)
+	if checker.NonNil(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx.Next()
+
+	return ctx.sendGRPCResponse(stream, route.method.Output())
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const apiKeyStorePrefix = "gopen:api-key:"
+
+type apiKeyResolver struct {
+	redis *redis.Client
+	mutex *sync.Mutex
+	files map[string]*apiKeyFile
+}
+
+type apiKeyFile struct {
+	modTime time.Time
+	keys    map[string]*vo.APIConsumer
+}
+
+// NewAPIKeyResolver creates the resolver over the shared store client, the store lookup is only available when the
+// client is informed.
+func NewAPIKeyResolver(client *redis.Client) app.APIKeyResolver {
+	return &apiKeyResolver{
+		redis: client,
+		mutex: &sync.Mutex{},
+		files: map[string]*apiKeyFile{},
+	}
+}
+
+func (a *apiKeyResolver) Resolve(ctx context.Context, config *vo.AuthAPIKeyConfig, key string) (*vo.APIConsumer,
+	error) {
+	if consumer, ok := config.Keys()[key]; ok {
+		return consumer, nil
+	}
+
+	if config.HasFile() {
+		keys, err := a.readFile(config.File())
+		if checker.NonNil(err) {
+			return nil, err
+		}
+		if consumer, ok := keys[key]; ok {
+			return consumer, nil
+		}
+	}
+
+	if config.Store() && checker.NonNil(a.redis) {
+		consumer, err := a.readStore(ctx, key)
+		if checker.NonNil(err) || checker.NonNil(consumer) {
+			return consumer, err
+		}
+	}
+
+	return nil, app.NewErrAuthUnauthenticated("api key not recognized")
+}
+
+// readFile reloads the JSON file, an array of keys like the config one, whenever its modification time changes.
+func (a *apiKeyResolver) readFile(path string) (map[string]*vo.APIConsumer, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	info, err := os.Stat(path)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "api key resolver failed: op=stat file=%s", path)
+	}
+
+	cached, ok := a.files[path]
+	if ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.keys, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "api key resolver failed: op=read file=%s", path)
+	}
+
+	var entries []dto.AuthAPIKeyEntry
+	err = json.Unmarshal(raw, &entries)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "api key resolver failed: op=unmarshal file=%s", path)
+	}
+
+	keys := map[string]*vo.APIConsumer{}
+	for _, entry := range entries {
+		keys[entry.Key] = vo.NewAPIConsumer(entry.Consumer, entry.Metadata)
+	}
+
+	a.files[path] = &apiKeyFile{
+		modTime: info.ModTime(),
+		keys:    keys,
+	}
+	return keys, nil
+}
+
+// readStore reads the entry saved as plain JSON under the gopen:api-key: prefix, returning nil when absent.
+func (a *apiKeyResolver) readStore(ctx context.Context, key string) (*vo.APIConsumer, error) {
+	raw, err := a.redis.Get(ctx, apiKeyStorePrefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "api key resolver failed: op=get-store")
+	}
+
+	var entry dto.AuthAPIKeyEntry
+	err = json.Unmarshal([]byte(raw), &entry)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "api key resolver failed: op=unmarshal-store")
+	}
+
+	return vo.NewAPIConsumer(entry.Consumer, entry.Metadata), nil
+}
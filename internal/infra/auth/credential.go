@@ -0,0 +1,190 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const credentialKeyPrefix = "auth:credential:"
+
+// credentialDefaultTTL is used when the token server does not inform the expires_in of the token.
+const credentialDefaultTTL = 5 * time.Minute
+
+type credentialProvider struct {
+	httpClient app.HTTPClient
+	store      domain.Store
+	group      *singleflight.Group
+}
+
+type credentialToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func NewCredentialProvider(httpClient app.HTTPClient, store domain.Store) app.CredentialProvider {
+	return credentialProvider{
+		httpClient: httpClient,
+		store:      store,
+		group:      &singleflight.Group{},
+	}
+}
+
+func (c credentialProvider) Token(ctx context.Context, config *vo.CredentialConfig, parent *vo.EndpointRequest,
+) (string, error) {
+	key := c.buildKey(config)
+
+	token, err := c.store.Get(ctx, key)
+	if checker.IsNil(err) {
+		return token, nil
+	} else if !errors.Is(err, domain.ErrCacheNotFound) {
+		return "", err
+	}
+
+	// concurrent misses share a single request, the context is detached so that the cancellation of the first caller
+	// does not fail the others.
+	result, err, _ := c.group.Do(key, func() (any, error) {
+		return c.refresh(context.WithoutCancel(ctx), config, parent, key)
+	})
+	if checker.NonNil(err) {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+func (c credentialProvider) Invalidate(ctx context.Context, config *vo.CredentialConfig, token string) {
+	key := c.buildKey(config)
+
+	// another request may already have refreshed the token, only the rejected one is discarded.
+	cached, err := c.store.Get(ctx, key)
+	if checker.IsNil(err) && checker.Equals(cached, token) {
+		_ = c.store.Del(ctx, key)
+	}
+}
+
+func (c credentialProvider) refresh(ctx context.Context, config *vo.CredentialConfig, parent *vo.EndpointRequest,
+	key string) (string, error) {
+	token, err := c.request(ctx, config, parent)
+	if checker.NonNil(err) {
+		return "", err
+	}
+
+	_ = c.store.Set(ctx, key, token.AccessToken, c.buildTTL(config, token))
+
+	return token.AccessToken, nil
+}
+
+// buildTTL discounts the refresh-before from the expires_in of the token, when the token lives less than that, it is
+// kept for half of its life so that every request does not reach the token server.
+func (c credentialProvider) buildTTL(config *vo.CredentialConfig, token *credentialToken) time.Duration {
+	if checker.IsLessThanOrEqual(token.ExpiresIn, 0) {
+		return credentialDefaultTTL
+	}
+
+	expiresIn := time.Duration(token.ExpiresIn) * time.Second
+	if checker.IsGreaterThan(expiresIn, config.RefreshBefore()) {
+		return expiresIn - config.RefreshBefore()
+	}
+	return expiresIn / 2
+}
+
+func (c credentialProvider) request(ctx context.Context, config *vo.CredentialConfig, parent *vo.EndpointRequest,
+) (*credentialToken, error) {
+	request, err := c.buildRequest(config)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	response, err := c.httpClient.MakeRequest(ctx, nil, parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "credential failed: op=request credential=%s", config.Name())
+	}
+	defer response.Body.Close()
+
+	if checker.NotEquals(response.StatusCode, http.StatusOK) {
+		return nil, errors.Newf("credential failed: op=request credential=%s status=%d", config.Name(),
+			response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "credential failed: op=read credential=%s", config.Name())
+	}
+
+	var token credentialToken
+	err = json.Unmarshal(body, &token)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "credential failed: op=unmarshal credential=%s", config.Name())
+	} else if checker.IsEmpty(token.AccessToken) {
+		return nil, errors.Newf("credential failed: op=unmarshal credential=%s access_token is empty", config.Name())
+	}
+
+	return &token, nil
+}
+
+func (c credentialProvider) buildRequest(config *vo.CredentialConfig) (*vo.HTTPBackendRequest, error) {
+	parsedURL, err := url.Parse(config.TokenURL())
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "credential failed: op=parse-url credential=%s", config.Name())
+	}
+
+	form := url.Values{"grant_type": []string{"client_credentials"}}
+	if config.HasScopes() {
+		form.Set("scope", strings.Join(config.Scopes(), " "))
+	}
+	if config.HasAudience() {
+		form.Set("audience", config.Audience())
+	}
+
+	credentials := fmt.Sprint(url.QueryEscape(config.ClientID()), ":", url.QueryEscape(config.ClientSecret()))
+	header := map[string][]string{
+		"Accept":        {"application/json"},
+		"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))},
+	}
+
+	return vo.NewHTTPBackendRequest(
+		vo.NewEmptyDegradation(),
+		fmt.Sprint(parsedURL.Scheme, "://", parsedURL.Host),
+		http.MethodPost,
+		vo.NewURLPath(parsedURL.Path, nil),
+		vo.NewMetadata(header),
+		vo.NewQuery(parsedURL.Query()),
+		vo.NewPayload("application/x-www-form-urlencoded", "", bytes.NewBufferString(form.Encode())),
+		nil,
+	), nil
+}
+
+func (c credentialProvider) buildKey(config *vo.CredentialConfig) string {
+	return credentialKeyPrefix + config.Name()
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+func TestCredentialProviderBuildTTL(t *testing.T) {
+	config := vo.NewCredentialConfig("api", "http://localhost/token", "id", "secret", nil, "",
+		vo.NewDuration(30*time.Second))
+
+	tests := []struct {
+		name      string
+		expiresIn int64
+		want      time.Duration
+	}{
+		{"not informed", 0, credentialDefaultTTL},
+		{"longer than refresh-before", 3600, 3600*time.Second - 30*time.Second},
+		{"equal to refresh-before", 30, 15 * time.Second},
+		{"shorter than refresh-before", 10, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := credentialProvider{}.buildTTL(config, &credentialToken{AccessToken: "token", ExpiresIn: tt.expiresIn})
+			if got != tt.want {
+				t.Errorf("buildTTL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const introspectionKeyPrefix = "auth:introspection:"
+
+type introspector struct {
+	httpClient app.HTTPClient
+	store      domain.Store
+}
+
+func NewIntrospector(httpClient app.HTTPClient, store domain.Store) app.TokenIntrospector {
+	return introspector{
+		httpClient: httpClient,
+		store:      store,
+	}
+}
+
+func (i introspector) Introspect(ctx context.Context, config *vo.AuthIntrospectionConfig, parent *vo.EndpointRequest,
+	token string) (map[string]any, error) {
+	key := i.buildKey(config, token)
+
+	claims, err := i.readCache(ctx, key)
+	if errors.Is(err, domain.ErrCacheNotFound) {
+		claims, err = i.request(ctx, config, parent, token)
+		if checker.NonNil(err) {
+			return nil, err
+		}
+		i.writeCache(ctx, config, key, claims)
+	} else if checker.NonNil(err) {
+		return nil, err
+	}
+
+	if active, _ := claims["active"].(bool); !active {
+		return nil, app.NewErrAuthUnauthenticated("token is not active")
+	}
+	return claims, nil
+}
+
+func (i introspector) request(ctx context.Context, config *vo.AuthIntrospectionConfig, parent *vo.EndpointRequest,
+	token string) (map[string]any, error) {
+	request, err := i.buildRequest(config, token)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	response, err := i.httpClient.MakeRequest(ctx, nil, parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "introspection failed: op=request url=%s", config.URL())
+	}
+	defer response.Body.Close()
+
+	if checker.NotEquals(response.StatusCode, http.StatusOK) {
+		return nil, errors.Newf("introspection failed: op=request url=%s status=%d", config.URL(),
+			response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "introspection failed: op=read url=%s", config.URL())
+	}
+
+	var claims map[string]any
+	err = json.Unmarshal(body, &claims)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "introspection failed: op=unmarshal url=%s", config.URL())
+	}
+
+	return claims, nil
+}
+
+func (i introspector) buildRequest(config *vo.AuthIntrospectionConfig, token string) (*vo.HTTPBackendRequest, error) {
+	parsedURL, err := url.Parse(config.URL())
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "introspection failed: op=parse-url url=%s", config.URL())
+	}
+
+	form := url.Values{"token": []string{token}}
+	if config.HasTokenTypeHint() {
+		form.Set("token_type_hint", config.TokenTypeHint())
+	}
+
+	header := map[string][]string{
+		"Accept": {"application/json"},
+	}
+	if config.HasClientCredentials() {
+		credentials := fmt.Sprint(url.QueryEscape(config.ClientID()), ":", url.QueryEscape(config.ClientSecret()))
+		header["Authorization"] = []string{"Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))}
+	}
+
+	return vo.NewHTTPBackendRequest(
+		vo.NewEmptyDegradation(),
+		fmt.Sprint(parsedURL.Scheme, "://", parsedURL.Host),
+		http.MethodPost,
+		vo.NewURLPath(parsedURL.Path, nil),
+		vo.NewMetadata(header),
+		vo.NewQuery(parsedURL.Query()),
+		vo.NewPayload("application/x-www-form-urlencoded", "", bytes.NewBufferString(form.Encode())),
+		nil,
+	), nil
+}
+
+func (i introspector) buildKey(config *vo.AuthIntrospectionConfig, token string) string {
+	sum := sha256.Sum256([]byte(config.URL() + ":" + token))
+	return introspectionKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+func (i introspector) readCache(ctx context.Context, key string) (map[string]any, error) {
+	value, err := i.store.Get(ctx, key)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	var claims map[string]any
+	err = json.Unmarshal([]byte(value), &claims)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "introspection failed: op=unmarshal-cache key=%s", key)
+	}
+	return claims, nil
+}
+
+// writeCache stores both active and inactive results, an active result never outlives the exp of the token.
+func (i introspector) writeCache(ctx context.Context, config *vo.AuthIntrospectionConfig, key string,
+	claims map[string]any) {
+	ttl := config.CacheTTL()
+	if exp, ok := claims["exp"].(float64); ok {
+		untilExp := time.Until(time.Unix(int64(exp), 0))
+		if checker.IsGreaterThan(untilExp, 0) && checker.IsLessThan(untilExp, ttl) {
+			ttl = untilExp
+		}
+	}
+
+	value, err := json.Marshal(claims)
+	if checker.NonNil(err) {
+		return
+	}
+	_ = i.store.Set(ctx, key, string(value), ttl)
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// jwksFileWatchInterval is how often a local JWKS document is checked for changes.
+const jwksFileWatchInterval = 5 * time.Second
+
+type jwtValidator struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	keyfuncs *sync.Map
+	loading  *singleflight.Group
+}
+
+func NewJWTValidator() app.JWTValidator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jwtValidator{
+		ctx:      ctx,
+		cancel:   cancel,
+		keyfuncs: &sync.Map{},
+		loading:  &singleflight.Group{},
+	}
+}
+
+func (v *jwtValidator) Validate(ctx context.Context, config *vo.AuthJWTConfig, token string) (map[string]any, error) {
+	kf, err := v.keyfunc(config)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = v.buildParser(config).ParseWithClaims(token, claims, kf.KeyfuncCtx(ctx))
+	if checker.NonNil(err) {
+		return nil, app.NewErrAuthUnauthenticated(err.Error())
+	}
+
+	return claims, nil
+}
+
+func (v *jwtValidator) Close() {
+	v.cancel()
+}
+
+func (v *jwtValidator) buildParser(config *vo.AuthJWTConfig) *jwt.Parser {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(config.Algorithms()),
+		jwt.WithLeeway(config.ClockSkew()),
+	}
+	if config.HasIssuer() {
+		opts = append(opts, jwt.WithIssuer(config.Issuer()))
+	}
+	if config.HasAudience() {
+		opts = append(opts, jwt.WithAudience(config.Audience()...))
+	}
+	return jwt.NewParser(opts...)
+}
+
+// keyfunc returns the keys of the JWKS source, loaded once by the first request using it while the other ones wait
+// only for that same source.
+func (v *jwtValidator) keyfunc(config *vo.AuthJWTConfig) (keyfunc.Keyfunc, error) {
+	source := config.JWKSFile()
+	if config.HasJWKSURL() {
+		source = config.JWKSURL()
+	}
+
+	if kf, ok := v.keyfuncs.Load(source); ok {
+		return kf.(keyfunc.Keyfunc), nil
+	}
+
+	kf, err, _ := v.loading.Do(source, func() (any, error) {
+		if kf, ok := v.keyfuncs.Load(source); ok {
+			return kf, nil
+		} else if config.HasJWKSURL() {
+			return v.loadKeyfuncByURL(config)
+		}
+		return v.loadKeyfuncByFile(config)
+	})
+	if checker.NonNil(err) {
+		return nil, err
+	}
+	return kf.(keyfunc.Keyfunc), nil
+}
+
+// loadKeyfuncByURL keeps one JWKS storage per URL, refreshed in background on the configured interval and on demand
+// when a token is signed by an unknown kid, so that rotated keys are picked up without restarting.
+func (v *jwtValidator) loadKeyfuncByURL(config *vo.AuthJWTConfig) (keyfunc.Keyfunc, error) {
+	kf, err := keyfunc.NewDefaultOverrideCtx(v.ctx, []string{config.JWKSURL()}, keyfunc.Override{
+		RefreshInterval:   config.JWKSRefresh(),
+		RefreshUnknownKID: rate.NewLimiter(rate.Every(time.Minute), 1),
+	})
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "jwt validator failed: op=fetch-jwks url=%s", config.JWKSURL())
+	}
+
+	v.keyfuncs.Store(config.JWKSURL(), kf)
+	return kf, nil
+}
+
+// loadKeyfuncByFile reads the local JWKS document and watches it in background, reloading it whenever its
+// modification time changes.
+func (v *jwtValidator) loadKeyfuncByFile(config *vo.AuthJWTConfig) (keyfunc.Keyfunc, error) {
+	path := config.JWKSFile()
+
+	info, err := os.Stat(path)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "jwt validator failed: op=stat-jwks file=%s", path)
+	}
+
+	kf, err := v.readKeyfuncFile(path)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	v.keyfuncs.Store(path, kf)
+	go v.watchKeyfuncFile(path, info.ModTime())
+
+	return kf, nil
+}
+
+// watchKeyfuncFile keeps the previous keys while the changed document can not be read.
+func (v *jwtValidator) watchKeyfuncFile(path string, modTime time.Time) {
+	ticker := time.NewTicker(jwksFileWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if checker.NonNil(err) || info.ModTime().Equal(modTime) {
+			continue
+		}
+
+		kf, err := v.readKeyfuncFile(path)
+		if checker.NonNil(err) {
+			continue
+		}
+
+		modTime = info.ModTime()
+		v.keyfuncs.Store(path, kf)
+	}
+}
+
+func (v *jwtValidator) readKeyfuncFile(path string) (keyfunc.Keyfunc, error) {
+	raw, err := os.ReadFile(path)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "jwt validator failed: op=read-jwks file=%s", path)
+	}
+
+	kf, err := keyfunc.NewJWKSetJSON(raw)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "jwt validator failed: op=parse-jwks file=%s", path)
+	}
+	return kf, nil
+}
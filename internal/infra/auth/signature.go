@@ -0,0 +1,117 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/canonical"
+)
+
+type signatureVerifier struct {
+}
+
+func NewSignatureVerifier() app.SignatureVerifier {
+	return signatureVerifier{}
+}
+
+func (s signatureVerifier) Verify(config *vo.AuthSignatureConfig, request *vo.EndpointRequest) error {
+	secret := os.Getenv(config.SecretEnv())
+	if checker.IsEmpty(secret) {
+		return errors.Newf("signature verifier failed: env=%s not defined", config.SecretEnv())
+	}
+
+	signature, ok := strings.CutPrefix(request.Metadata().GetFirst(config.Header()), config.Prefix())
+	if !ok || checker.IsEmpty(signature) {
+		return app.NewErrAuthUnauthenticated("signature not informed")
+	}
+
+	received, err := s.decode(config.Encoding(), signature)
+	if checker.NonNil(err) {
+		return app.NewErrAuthUnauthenticated("signature malformed")
+	}
+
+	timestamp, err := s.checkTimestamp(config, request)
+	if checker.NonNil(err) {
+		return err
+	}
+
+	mac := hmac.New(canonical.HashFunc(config.Algorithm()), []byte(secret))
+	mac.Write(s.buildCanonical(config, request, timestamp))
+
+	if !hmac.Equal(mac.Sum(nil), received) {
+		return app.NewErrAuthUnauthenticated("signature mismatch")
+	}
+	return nil
+}
+
+// checkTimestamp enforces the replay window, the header accepts unix time in seconds or milliseconds.
+func (s signatureVerifier) checkTimestamp(config *vo.AuthSignatureConfig, request *vo.EndpointRequest) (string,
+	error) {
+	if !config.HasTimestamp() {
+		return "", nil
+	}
+
+	timestamp := request.Metadata().GetFirst(config.TimestampHeader())
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if checker.NonNil(err) {
+		return "", app.NewErrAuthUnauthenticated("signature timestamp malformed")
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if checker.IsGreaterThan(unix, int64(1e12)) {
+		signedAt = time.UnixMilli(unix)
+	}
+
+	if checker.IsGreaterThan(math.Abs(float64(time.Since(signedAt))), float64(config.Tolerance())) {
+		return "", app.NewErrAuthUnauthenticated("signature timestamp outside the tolerance")
+	}
+	return timestamp, nil
+}
+
+func (s signatureVerifier) buildCanonical(config *vo.AuthSignatureConfig, request *vo.EndpointRequest,
+	timestamp string) []byte {
+	var body string
+	if request.HasPayload() {
+		body = string(request.Payload().RawBytes())
+	}
+
+	return canonical.Build(config.Canonical(), map[string]string{
+		"timestamp": timestamp,
+		"body":      body,
+	}, request.Metadata().GetFirst)
+}
+
+func (s signatureVerifier) decode(encoding enum.SignatureEncoding, signature string) ([]byte, error) {
+	if checker.Equals(encoding, enum.SignatureEncodingBase64) {
+		return base64.StdEncoding.DecodeString(signature)
+	}
+	return hex.DecodeString(strings.ToLower(signature))
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const signatureSecretEnv = "GOPEN_TEST_SIGNATURE_SECRET"
+
+func signatureTestConfig(encoding enum.SignatureEncoding, timestampHeader string) *vo.AuthSignatureConfig {
+	return vo.NewAuthSignatureConfig("X-Signature", "sha256=", enum.HMACAlgorithmSHA256, encoding,
+		signatureSecretEnv, "{timestamp}.{header.X-Id}.{body}", timestampHeader, vo.NewDuration(time.Minute))
+}
+
+func signatureTestRequest(header map[string][]string, body string) *vo.EndpointRequest {
+	return vo.NewConsumerEndpointRequest("id", "trace", "webhook", "POST", vo.NewMetadata(header),
+		vo.NewPayloadJSON(bytes.NewBufferString(body)))
+}
+
+func signatureTestSign(canonical string) []byte {
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(canonical))
+	return mac.Sum(nil)
+}
+
+func TestSignatureVerifierVerify(t *testing.T) {
+	t.Setenv(signatureSecretEnv, "secret")
+
+	body := `{"id":1}`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	signature := signatureTestSign(timestamp + ".42." + body)
+
+	tests := []struct {
+		name     string
+		config   *vo.AuthSignatureConfig
+		header   map[string][]string
+		body     string
+		wantAuth bool
+	}{
+		{
+			name:   "hex",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + hex.EncodeToString(signature)},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"42"},
+			},
+			body:     body,
+			wantAuth: true,
+		},
+		{
+			name:   "base64",
+			config: signatureTestConfig(enum.SignatureEncodingBase64, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + base64.StdEncoding.EncodeToString(signature)},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"42"},
+			},
+			body:     body,
+			wantAuth: true,
+		},
+		{
+			name:   "tampered body",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + hex.EncodeToString(signature)},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"42"},
+			},
+			body: `{"id":2}`,
+		},
+		{
+			name:   "tampered header",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + hex.EncodeToString(signature)},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"43"},
+			},
+			body: body,
+		},
+		{
+			name:   "missing prefix",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {hex.EncodeToString(signature)},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"42"},
+			},
+			body: body,
+		},
+		{
+			name:   "malformed",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=zz"},
+				"X-Timestamp": {timestamp},
+				"X-Id":        {"42"},
+			},
+			body: body,
+		},
+		{
+			name:   "timestamp outside the tolerance",
+			config: signatureTestConfig(enum.SignatureEncodingHex, "X-Timestamp"),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + hex.EncodeToString(signatureTestSign(expired+".42."+body))},
+				"X-Timestamp": {expired},
+				"X-Id":        {"42"},
+			},
+			body: body,
+		},
+		{
+			name:   "body with placeholders",
+			config: signatureTestConfig(enum.SignatureEncodingHex, ""),
+			header: map[string][]string{
+				"X-Signature": {"sha256=" + hex.EncodeToString(signatureTestSign(".42.{timestamp}{header.X-Id}"))},
+				"X-Id":        {"42"},
+			},
+			body:     "{timestamp}{header.X-Id}",
+			wantAuth: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewSignatureVerifier().Verify(tt.config, signatureTestRequest(tt.header, tt.body))
+			if tt.wantAuth && err != nil {
+				t.Errorf("Verify() error = %v, want nil", err)
+			} else if !tt.wantAuth && !errors.Is(err, app.ErrAuthUnauthenticated) {
+				t.Errorf("Verify() error = %v, want unauthenticated", err)
+			}
+		})
+	}
+}
+
+func TestSignatureVerifierVerifyWithoutSecret(t *testing.T) {
+	t.Setenv(signatureSecretEnv, "")
+
+	request := signatureTestRequest(map[string][]string{"X-Signature": {"sha256=00"}}, "{}")
+	err := NewSignatureVerifier().Verify(signatureTestConfig(enum.SignatureEncodingHex, ""), request)
+	if err == nil || errors.Is(err, app.ErrAuthUnauthenticated) {
+		t.Errorf("Verify() error = %v, want configuration error", err)
+	}
+}
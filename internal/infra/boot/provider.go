@@ -30,6 +30,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/fsnotify/fsnotify"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/tech4works/checker"
@@ -39,15 +41,21 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/app"
 	"github.com/tech4works/gopen-gateway/internal/app/model/dto"
 	"github.com/tech4works/gopen-gateway/internal/app/server"
+	"github.com/tech4works/gopen-gateway/internal/domain"
 	"github.com/tech4works/gopen-gateway/internal/infra/api"
+	"github.com/tech4works/gopen-gateway/internal/infra/auth"
 	"github.com/tech4works/gopen-gateway/internal/infra/cache"
+	"github.com/tech4works/gopen-gateway/internal/infra/consumer"
 	"github.com/tech4works/gopen-gateway/internal/infra/convert"
+	"github.com/tech4works/gopen-gateway/internal/infra/descriptor"
+	"github.com/tech4works/gopen-gateway/internal/infra/grpc"
 	"github.com/tech4works/gopen-gateway/internal/infra/http"
 	"github.com/tech4works/gopen-gateway/internal/infra/jsonpath"
 	"github.com/tech4works/gopen-gateway/internal/infra/log"
 	"github.com/tech4works/gopen-gateway/internal/infra/nomenclature"
 	"github.com/tech4works/gopen-gateway/internal/infra/publisher"
 	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
+	"github.com/tech4works/gopen-gateway/internal/infra/websocket"
 )
 
 const runtimeFolder = "./runtime"
@@ -115,11 +123,18 @@ func (p provider) Start(gopen *dto.Gopen) {
 
 	p.log.PrintInfo("Configuring cache store...")
 	store := cache.NewMemoryStore()
+	var redisClient *redis.Client
 	if checker.NonNil(gopen.Store) {
-		store = cache.NewRedisStore(gopen.Store.Redis.Address, gopen.Store.Redis.Password)
+		redisClient = cache.NewRedisClient(gopen.Store.Redis.Address, gopen.Store.Redis.Password)
+		store = cache.NewRedisStore(redisClient)
 	}
 	defer store.Close()
 
+	var rateStore domain.RateStore
+	if checker.NonNil(gopen.Store) {
+		rateStore = cache.NewRedisRateStore(redisClient)
+	}
+
 	p.log.PrintInfo("Configuring publishers clients...")
 	var sqsClient *sqs.Client
 	var snsClient *sns.Client
@@ -130,7 +145,46 @@ func (p provider) Start(gopen *dto.Gopen) {
 		snsClient = sns.NewFromConfig(awsConfig)
 	}
 
-	err := p.writeRuntimeJSON(gopen)
+	var err error
+	var kafkaClient *kgo.Client
+	if checker.NonNil(gopen.Brokers) && checker.NonNil(gopen.Brokers.Kafka) {
+		kafkaClient, err = publisher.NewKafkaClient(gopen.Brokers.Kafka.Addresses, gopen.Brokers.Kafka.ClientID)
+		if checker.NonNil(err) {
+			p.log.PrintWarn("Error configure kafka client:", err)
+		} else {
+			defer kafkaClient.Close()
+		}
+	}
+
+	var amqpConnection *publisher.AMQPConnection
+	if checker.NonNil(gopen.Brokers) && checker.NonNil(gopen.Brokers.AMQP) {
+		amqpConnection = publisher.NewAMQPConnection(gopen.Brokers.AMQP.URL)
+		defer amqpConnection.Close()
+	}
+
+	var natsConnection *publisher.NATSConnection
+	if checker.NonNil(gopen.Brokers) && checker.NonNil(gopen.Brokers.NATS) {
+		natsConnection, err = publisher.NewNATSConnection(gopen.Brokers.NATS.URL, gopen.Brokers.NATS.Name,
+			gopen.Brokers.NATS.JetStream)
+		if checker.NonNil(err) {
+			p.log.PrintWarn("Error configure nats connection:", err)
+		} else {
+			defer natsConnection.Close()
+		}
+	}
+
+	var redisStream *publisher.RedisStream
+	if checker.NonNil(gopen.Store) {
+		var maxLen int64
+		var approximate bool
+		if checker.NonNil(gopen.Brokers) && checker.NonNil(gopen.Brokers.RedisStream) {
+			maxLen = gopen.Brokers.RedisStream.MaxLen
+			approximate = gopen.Brokers.RedisStream.Approximate
+		}
+		redisStream = publisher.NewRedisStream(redisClient, maxLen, approximate)
+	}
+
+	err = p.writeRuntimeJSON(gopen)
 	if checker.NonNil(err) {
 		p.log.PrintWarn(err)
 	}
@@ -143,14 +197,29 @@ func (p provider) Start(gopen *dto.Gopen) {
 
 	p.log.PrintInfo("Building server...")
 	router := api.NewRouter()
-	httpClient := http.NewClient(gopen, p.log)
-	publisherClient := publisher.NewClient(sqsClient, snsClient)
+	descriptors := descriptor.NewRegistry()
+	grpcRouter := api.NewGRPCRouter(descriptors)
+	httpClient := http.NewClient(gopen, awsConfig, p.log)
+	publisherClient := publisher.NewClient(sqsClient, snsClient, kafkaClient, amqpConnection,
+		natsConnection, redisStream)
+	webSocketClient := websocket.NewClient()
+	grpcClient := grpc.NewClient(descriptors)
+	defer grpcClient.Close()
+	consumerClient := consumer.NewClient(sqsClient, p.buildConsumerKafkaConfig(gopen), p.log)
+	jwtValidator := auth.NewJWTValidator()
+	defer jwtValidator.Close()
+	tokenIntrospector := auth.NewIntrospector(httpClient, store)
+	apiKeyResolver := auth.NewAPIKeyResolver(redisClient)
+	signatureVerifier := auth.NewSignatureVerifier()
+	credentialProvider := auth.NewCredentialProvider(httpClient, store)
 	jsonPath := jsonpath.New()
 	nConverter := convert.New()
 	nNomenclature := nomenclature.New()
 
-	httpServer := server.New(gopen, p.log, router, httpClient, publisherClient, middlewareLog, endpointLog, backendLog,
-		httpLog, jsonPath, nConverter, store, nNomenclature)
+	httpServer := server.New(gopen, p.log, router, grpcRouter, httpClient, publisherClient, webSocketClient,
+		grpcClient, consumerClient, jwtValidator, tokenIntrospector, apiKeyResolver, signatureVerifier,
+		credentialProvider, middlewareLog, endpointLog, backendLog, httpLog, jsonPath, nConverter, store, rateStore,
+		nNomenclature)
 
 	p.httpServer = httpServer
 
@@ -359,6 +428,16 @@ func (p provider) validateJSONBySchema(jsonBytes []byte) error {
 	return err
 }
 
+func (p provider) buildConsumerKafkaConfig(gopen *dto.Gopen) *consumer.KafkaConfig {
+	if checker.IsNil(gopen.Brokers) || checker.IsNil(gopen.Brokers.Kafka) {
+		return nil
+	}
+	return &consumer.KafkaConfig{
+		Addresses: gopen.Brokers.Kafka.Addresses,
+		ClientID:  gopen.Brokers.Kafka.ClientID,
+	}
+}
+
 func (p provider) writeRuntimeJSON(gopen *dto.Gopen) error {
 	if _, err := os.Stat(runtimeFolder); os.IsNotExist(err) {
 		err = os.MkdirAll(runtimeFolder, 0755)
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/jellydator/ttlcache/v2"
@@ -33,8 +34,15 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
 )
 
+// memoryTag indexes the keys of the entries carrying a tag, expiring along with the longest of them.
+type memoryTag struct {
+	keys      map[string]bool
+	expiresAt time.Time
+}
+
 type memoryStore struct {
 	ttlCache *ttlcache.Cache
+	mutex    *sync.Mutex
 }
 
 func NewMemoryStore() domain.Store {
@@ -42,6 +50,7 @@ func NewMemoryStore() domain.Store {
 	ttlCache.SkipTTLExtensionOnHit(true)
 	return &memoryStore{
 		ttlCache: ttlCache,
+		mutex:    &sync.Mutex{},
 	}
 }
 
@@ -61,10 +70,106 @@ func (m memoryStore) Set(ctx context.Context, key, value string, ttl time.Durati
 	return m.ttlCache.SetWithTTL(key, b64, ttl)
 }
 
+func (m memoryStore) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	_, span := telemetry.Tracer().Start(ctx, "cache/local lock")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cache.key", key))
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, err := m.ttlCache.Get(key)
+	if checker.IsNil(err) {
+		return false, nil
+	} else if !errors.Is(err, ttlcache.ErrNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	return true, m.ttlCache.SetWithTTL(key, value, ttl)
+}
+
 func (m memoryStore) Del(_ context.Context, key string) error {
 	return m.ttlCache.Remove(key)
 }
 
+func (m memoryStore) DelIfEquals(_ context.Context, key, value string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	current, err := m.ttlCache.Get(key)
+	if errors.Is(err, ttlcache.ErrNotFound) {
+		return nil
+	} else if checker.NonNil(err) {
+		return err
+	} else if checker.NotEquals(current, value) {
+		return nil
+	}
+	return m.ttlCache.Remove(key)
+}
+
+func (m memoryStore) DelByPattern(_ context.Context, pattern string) error {
+	for _, key := range m.ttlCache.GetKeys() {
+		if globMatch(pattern, key) {
+			_ = m.ttlCache.Remove(key)
+		}
+	}
+	return nil
+}
+
+func (m memoryStore) Tag(ctx context.Context, key string, tags []string, ttl time.Duration) error {
+	_, span := telemetry.Tracer().Start(ctx, "cache/local tag")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cache.key", key),
+		attribute.StringSlice("cache.tags", tags),
+	)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	for _, tag := range tags {
+		index := &memoryTag{keys: map[string]bool{}, expiresAt: expiresAt}
+		if value, err := m.ttlCache.Get(tag); checker.IsNil(err) {
+			index = value.(*memoryTag)
+		}
+
+		index.keys[key] = true
+		if expiresAt.After(index.expiresAt) {
+			index.expiresAt = expiresAt
+		}
+
+		err := m.ttlCache.SetWithTTL(tag, index, time.Until(index.expiresAt))
+		if checker.NonNil(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func (m memoryStore) DelByTag(_ context.Context, tag string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, err := m.ttlCache.Get(tag)
+	if errors.Is(err, ttlcache.ErrNotFound) {
+		return nil
+	} else if checker.NonNil(err) {
+		return err
+	}
+
+	for key := range value.(*memoryTag).keys {
+		_ = m.ttlCache.Remove(key)
+	}
+	return m.ttlCache.Remove(tag)
+}
+
 func (m memoryStore) Get(ctx context.Context, key string) (string, error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "cache/local read")
 	defer span.End()
@@ -86,3 +191,53 @@ func (m memoryStore) Get(ctx context.Context, key string) (string, error) {
 func (m memoryStore) Close() error {
 	return nil
 }
+
+// globMatch follows the Redis glob, '*' matches any sequence and '?' any single character, '/' included unlike
+// path.Match. The key is walked once, backtracking only to the latest '*', so the cost is bounded by the length of
+// the pattern times the length of the key.
+func globMatch(pattern, key string) bool {
+	p, k := 0, 0
+	star, starKey := -1, 0
+
+	for k < len(key) {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				star, starKey = p, k
+				p++
+				continue
+			case '?':
+				p++
+				k++
+				continue
+			case '\\':
+				literal, width := pattern[p], 1
+				if p+1 < len(pattern) {
+					literal, width = pattern[p+1], 2
+				}
+				if literal == key[k] {
+					p += width
+					k++
+					continue
+				}
+			default:
+				if pattern[p] == key[k] {
+					p++
+					k++
+					continue
+				}
+			}
+		}
+
+		if star < 0 {
+			return false
+		}
+		starKey++
+		p, k = star+1, starKey
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
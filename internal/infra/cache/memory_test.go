@@ -0,0 +1,60 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"ENDPOINT:*", "ENDPOINT:GET:/users/1", true},
+		{"ENDPOINT:*", "BACKEND:GET:/users/1", false},
+		{"*:/users/*", "BACKEND:GET:/users/1/orders", true},
+		{"ENDPOINT:GET:/users/?", "ENDPOINT:GET:/users/1", true},
+		{"ENDPOINT:GET:/users/?", "ENDPOINT:GET:/users/10", false},
+		{"ENDPOINT:*:/users/*/orders", "ENDPOINT:GET:/users/1/orders", true},
+		{"ENDPOINT:*:/users/*/orders", "ENDPOINT:GET:/users/1/orders/2", false},
+		{"ENDPOINT:\\*", "ENDPOINT:*", true},
+		{"ENDPOINT:\\*", "ENDPOINT:GET", false},
+		{"ENDPOINT:\\?", "ENDPOINT:a", false},
+		{"ENDPOINT:\\", "ENDPOINT:\\", true},
+		{"ENDPOINT:**", "ENDPOINT:", true},
+		{"", "", true},
+		{"", "ENDPOINT:", false},
+		{"*", "", true},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.key); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestGlobMatchManyStars(t *testing.T) {
+	pattern := strings.Repeat("*a", 100) + "b"
+	key := strings.Repeat("a", 500)
+
+	if globMatch(pattern, key) {
+		t.Errorf("globMatch(%q, %q) = true, want false", pattern, key)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/domain"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
+)
+
+const rateStorePrefix = "gopen:limiter:rate:"
+
+// tokenBucketScript refills one token per interval (in microseconds) up to the capacity and consumes one when
+// available. The clock comes from the Redis server so every gateway instance shares the same time reference. It
+// returns the decision, the remaining tokens, the microseconds until the bucket is full and until the next token.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) / interval)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * interval)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * interval / 1000) + 1000)
+
+return {allowed, math.floor(tokens), math.ceil((capacity - tokens) * interval), retry}
+`)
+
+// slidingWindowScript admits at most the capacity within the last window (in microseconds), each admitted request
+// being a member of a sorted set scored by its arrival time. It returns the same values as the token bucket, the
+// bucket being full once the newest request leaves the window and accepting again once the oldest one does.
+var slidingWindowScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+if count >= capacity then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+	return {0, 0, tonumber(newest[2]) + window - now, tonumber(oldest[2]) + window - now}
+end
+
+redis.call('ZADD', KEYS[1], now, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000) + 1000)
+
+return {1, capacity - count - 1, window, 0}
+`)
+
+type redisRateStore struct {
+	client *redis.Client
+}
+
+// NewRedisRateStore counts on the client of the Redis declared as the global store, which is closed with the store.
+func NewRedisRateStore(client *redis.Client) domain.RateStore {
+	return &redisRateStore{
+		client: client,
+	}
+}
+
+func (r redisRateStore) Allow(ctx context.Context, algorithm enum.LimiterAlgorithm, key string, capacity int,
+	every time.Duration) (*vo.LimiterRateStatus, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "limiter/rate distributed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("limiter.key", key),
+		attribute.String("limiter.algorithm", string(algorithm)),
+	)
+
+	keys := []string{rateStorePrefix + key}
+	interval := max(every.Microseconds(), 1)
+
+	var values []int64
+	var err error
+	switch algorithm {
+	case enum.LimiterAlgorithmSlidingWindow:
+		values, err = slidingWindowScript.Run(ctx, r.client, keys, capacity, interval, uuid.New().String()).Int64Slice()
+	default:
+		values, err = tokenBucketScript.Run(ctx, r.client, keys, capacity, interval).Int64Slice()
+	}
+	if checker.IsNil(err) && checker.IsLengthNotEquals(values, 4) {
+		err = errors.Newf("rate store failed: op=run-script unexpected result=%v", values)
+	}
+	if checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	allowed := checker.Equals(values[0], int64(1))
+	span.SetAttributes(attribute.Bool("limiter.allowed", allowed))
+
+	window := every
+	if checker.NotEquals(algorithm, enum.LimiterAlgorithmSlidingWindow) {
+		window = every * time.Duration(capacity)
+	}
+
+	return vo.NewLimiterRateStatus(allowed, capacity, int(values[1]), window, time.Duration(values[2])*time.Microsecond,
+		time.Duration(values[3])*time.Microsecond), nil
+}
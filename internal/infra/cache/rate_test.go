@@ -0,0 +1,87 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+// newRedisTestClient connects to the Redis informed by GOPEN_TEST_REDIS_ADDRESS, skipping the test otherwise.
+func newRedisTestClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	address := os.Getenv("GOPEN_TEST_REDIS_ADDRESS")
+	if address == "" {
+		t.Skip("GOPEN_TEST_REDIS_ADDRESS not defined")
+	}
+
+	client := NewRedisClient(address, os.Getenv("GOPEN_TEST_REDIS_PASSWORD"))
+	t.Cleanup(func() { _ = client.Close() })
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("redis ping error = %v", err)
+	}
+	return client
+}
+
+func TestRedisRateStoreTokenBucket(t *testing.T) {
+	store := NewRedisRateStore(newRedisTestClient(t))
+	key := uuid.New().String()
+
+	for i, wantAllowed := range []bool{true, true, false} {
+		status, err := store.Allow(context.Background(), enum.LimiterAlgorithmTokenBucket, key, 2, time.Minute)
+		if err != nil {
+			t.Fatalf("Allow() request %d error = %v, want nil", i+1, err)
+		} else if status.Allowed() != wantAllowed {
+			t.Errorf("Allow() request %d allowed = %v, want %v", i+1, status.Allowed(), wantAllowed)
+		} else if !wantAllowed && status.RetryAfter() <= 0 {
+			t.Errorf("Allow() request %d retry after = %s, want positive", i+1, status.RetryAfter())
+		} else if status.Window() != 2*time.Minute {
+			t.Errorf("Allow() request %d window = %s, want %s", i+1, status.Window(), 2*time.Minute)
+		}
+	}
+}
+
+func TestRedisRateStoreSlidingWindow(t *testing.T) {
+	store := NewRedisRateStore(newRedisTestClient(t))
+	key := uuid.New().String()
+	every := 200 * time.Millisecond
+
+	for i, wantAllowed := range []bool{true, true, false} {
+		status, err := store.Allow(context.Background(), enum.LimiterAlgorithmSlidingWindow, key, 2, every)
+		if err != nil {
+			t.Fatalf("Allow() request %d error = %v, want nil", i+1, err)
+		} else if status.Allowed() != wantAllowed {
+			t.Errorf("Allow() request %d allowed = %v, want %v", i+1, status.Allowed(), wantAllowed)
+		}
+	}
+
+	time.Sleep(every + 50*time.Millisecond)
+
+	status, err := store.Allow(context.Background(), enum.LimiterAlgorithmSlidingWindow, key, 2, every)
+	if err != nil || !status.Allowed() {
+		t.Errorf("Allow() after the window = %+v, %v, want allowed", status, err)
+	}
+}
@@ -33,16 +33,42 @@ import (
 	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
 )
 
+// tagScript adds the entry to the tag set in KEYS[1] and only ever extends its expiration, so a tag outlives the
+// longest entry it indexes. It touches a single key, so each tag may live in a different cluster slot.
+var tagScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+redis.call('SADD', KEYS[1], ARGV[1])
+if redis.call('PTTL', KEYS[1]) < ttl then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+// delIfEqualsScript deletes the key only while it still holds the value, so a lock is released only by its holder and
+// never after it expired and was taken by another one.
+var delIfEqualsScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
 type redisStore struct {
 	client *redis.Client
 }
 
-func NewRedisStore(address, password string) domain.Store {
+// NewRedisClient opens the connection to the Redis declared as the global store, it is shared by everything using
+// that Redis and closed with the store.
+func NewRedisClient(address, password string) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: password,
+	})
+}
+
+func NewRedisStore(client *redis.Client) domain.Store {
 	return &redisStore{
-		client: redis.NewClient(&redis.Options{
-			Addr:     address,
-			Password: password,
-		}),
+		client: client,
 	}
 }
 
@@ -62,6 +88,21 @@ func (r redisStore) Set(ctx context.Context, key, value string, ttl time.Duratio
 	return r.client.Set(ctx, key, b64, ttl).Err()
 }
 
+func (r redisStore) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "cache/global lock")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cache.key", key))
+
+	ok, err := r.client.SetNX(ctx, key, value, ttl).Result()
+	if checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+	return ok, nil
+}
+
 func (r redisStore) Del(ctx context.Context, key string) error {
 	ctx, span := telemetry.Tracer().Start(ctx, "cache/global delete")
 	defer span.End()
@@ -71,6 +112,108 @@ func (r redisStore) Del(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r redisStore) DelIfEquals(ctx context.Context, key, value string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "cache/global unlock")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cache.key", key))
+
+	err := delIfEqualsScript.Run(ctx, r.client, []string{key}, value).Err()
+	if checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DelByPattern scans the keys matching the glob pattern in batches, so the server is never blocked as it would be
+// by KEYS.
+func (r redisStore) DelByPattern(ctx context.Context, pattern string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "cache/global delete by pattern")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cache.pattern", pattern))
+
+	iter := r.client.Scan(ctx, 0, pattern, 1000).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if checker.IsLengthGreaterThanOrEqual(keys, 1000) {
+			if err := r.client.Unlink(ctx, keys...).Err(); checker.NonNil(err) {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	} else if checker.IsEmpty(keys) {
+		return nil
+	}
+
+	return r.client.Unlink(ctx, keys...).Err()
+}
+
+// Tag runs the tagScript once per tag in a single pipeline.
+func (r redisStore) Tag(ctx context.Context, key string, tags []string, ttl time.Duration) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "cache/global tag")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("cache.key", key),
+		attribute.StringSlice("cache.tags", tags),
+	)
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			tagScript.Eval(ctx, pipe, []string{tag}, key, ttl.Milliseconds())
+		}
+		return nil
+	})
+	if checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DelByTag scans the members of the tag set in batches, deleting them along with the set itself.
+func (r redisStore) DelByTag(ctx context.Context, tag string) error {
+	ctx, span := telemetry.Tracer().Start(ctx, "cache/global delete by tag")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cache.tag", tag))
+
+	iter := r.client.SScan(ctx, tag, 0, "", 1000).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if checker.IsLengthGreaterThanOrEqual(keys, 1000) {
+			if err := r.client.Unlink(ctx, keys...).Err(); checker.NonNil(err) {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); checker.NonNil(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return r.client.Unlink(ctx, append(keys, tag)...).Err()
+}
+
 func (r redisStore) Get(ctx context.Context, key string) (string, error) {
 	ctx, span := telemetry.Tracer().Start(ctx, "cache/global read")
 	defer span.End()
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRedisStoreTag(t *testing.T) {
+	client := newRedisTestClient(t)
+	store := NewRedisStore(client)
+	ctx := context.Background()
+
+	prefix := "gopen:test:" + uuid.New().String()
+	tags := []string{prefix + ":tag:users", prefix + ":tag:orders"}
+	defer client.Del(ctx, append(tags, prefix+":a", prefix+":b")...)
+
+	for key, ttl := range map[string]time.Duration{prefix + ":a": time.Minute, prefix + ":b": time.Hour} {
+		if err := store.Set(ctx, key, "value", ttl); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		} else if err = store.Tag(ctx, key, tags, ttl); err != nil {
+			t.Fatalf("Tag() error = %v", err)
+		}
+	}
+	// a shorter entry tagged later never shortens the tag
+	if err := store.Tag(ctx, prefix+":a", tags, time.Minute); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+
+	for _, tag := range tags {
+		if ttl := client.PTTL(ctx, tag).Val(); ttl <= time.Minute {
+			t.Errorf("PTTL(%s) = %s, want the longest entry ttl", tag, ttl)
+		} else if members := client.SCard(ctx, tag).Val(); members != 2 {
+			t.Errorf("SCard(%s) = %d, want 2", tag, members)
+		}
+	}
+
+	if err := store.DelByTag(ctx, tags[0]); err != nil {
+		t.Fatalf("DelByTag() error = %v", err)
+	} else if exists := client.Exists(ctx, prefix+":a", prefix+":b", tags[0]).Val(); exists != 0 {
+		t.Errorf("Exists() = %d after DelByTag, want 0", exists)
+	}
+}
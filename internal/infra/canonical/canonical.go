@@ -0,0 +1,59 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canonical
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"regexp"
+	"strings"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+const headerPlaceholderPrefix = "header."
+
+var placeholderRegex = regexp.MustCompile(`\{([^{}]+)}`)
+
+// Build replaces every placeholder of the template in a single pass, '{header.<name>}' by the header returned by
+// header and the others by their values, so a replaced content, like the body, is never interpreted as a placeholder.
+// Unknown placeholders are kept as they are.
+func Build(template string, values map[string]string, header func(name string) string) []byte {
+	return []byte(placeholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if headerName, ok := strings.CutPrefix(name, headerPlaceholderPrefix); ok {
+			return header(headerName)
+		} else if value, ok := values[name]; ok {
+			return value
+		}
+		return placeholder
+	}))
+}
+
+// HashFunc returns the hash of the HMAC algorithm, SHA-256 by default.
+func HashFunc(algorithm enum.HMACAlgorithm) func() hash.Hash {
+	switch algorithm {
+	case enum.HMACAlgorithmSHA1:
+		return sha1.New
+	case enum.HMACAlgorithmSHA512:
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package canonical
+
+import "testing"
+
+func TestBuild(t *testing.T) {
+	header := func(name string) string {
+		return map[string]string{"X-Id": "42"}[name]
+	}
+	values := map[string]string{
+		"timestamp": "1700000000",
+		"body":      `{"note":"{timestamp} {header.X-Id}"}`,
+	}
+
+	got := string(Build("{timestamp}.{header.X-Id}.{header.X-Missing}.{unknown}.{body}", values, header))
+	want := `1700000000.42..{unknown}.{"note":"{timestamp} {header.X-Id}"}`
+	if got != want {
+		t.Errorf("Build() = %q, want %q", got, want)
+	}
+}
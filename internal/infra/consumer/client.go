@@ -0,0 +1,124 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/telemetry"
+)
+
+const receiveErrorBackoff = time.Second
+
+type client struct {
+	sqs   *sqs.Client
+	kafka *KafkaConfig
+	log   app.BootLog
+}
+
+// KafkaConfig holds the cluster connection, each KAFKA consumer opens its own client joined to its group.
+type KafkaConfig struct {
+	Addresses []string
+	ClientID  string
+}
+
+func NewClient(sqsClient *sqs.Client, kafkaConfig *KafkaConfig, log app.BootLog) app.ConsumerClient {
+	return client{
+		sqs:   sqsClient,
+		kafka: kafkaConfig,
+		log:   log,
+	}
+}
+
+// Consume blocks receiving the messages until ctx is done. The handler is called with a ctx detached from the
+// cancellation, so the in-flight message finishes and is acknowledged during the shutdown.
+func (c client) Consume(ctx context.Context, consumer *vo.ConsumerConfig, handler app.ConsumerHandler) error {
+	switch consumer.Broker() {
+	case enum.BackendBrokerAwsSqs:
+		return c.consumeSQS(ctx, consumer, handler)
+	case enum.BackendBrokerKafka:
+		return c.consumeKafka(ctx, consumer, handler)
+	default:
+		return errors.Newf("consumer failed: id=%s broker=%s reason=not implemented", consumer.ID(),
+			consumer.Broker())
+	}
+}
+
+func (c client) startSpan(ctx context.Context, consumer *vo.ConsumerConfig, system string) (context.Context,
+	trace.Span) {
+	ctx, span := telemetry.Tracer().Start(ctx, fmt.Sprintf("consumer/%s process", consumer.ID()),
+		trace.WithSpanKind(trace.SpanKindConsumer))
+	span.SetAttributes(
+		attribute.String("messaging.system", system),
+		attribute.String("messaging.operation.name", "process"),
+		attribute.String("messaging.operation.type", "process"),
+		attribute.String("messaging.destination.name", consumer.Path()),
+		attribute.String("messaging.consumer.group.name", consumer.Group()),
+	)
+	return ctx, span
+}
+
+// buildRequest turns the message into the endpoint request, a JSON body keeps the JSON content type and any other
+// is handled as plain text.
+func (c client) buildRequest(consumer *vo.ConsumerConfig, span trace.Span, header http.Header, body []byte,
+) *vo.EndpointRequest {
+	var traceID string
+	if span.SpanContext().IsValid() {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	var payload *vo.Payload
+	if checker.IsJSON(body) {
+		payload = vo.NewPayloadJSON(bytes.NewBuffer(body))
+	} else if checker.IsNotEmpty(body) {
+		payload = vo.NewPayloadWithContentType(vo.NewContentTypeTextPlain(), bytes.NewBuffer(body))
+	}
+
+	return vo.NewConsumerEndpointRequest(uuid.New().String(), traceID, consumer.Path(),
+		consumer.Endpoint().Method(), vo.NewMetadata(header), payload)
+}
+
+// wait sleeps the duration, returning false when ctx is done before.
+func (c client) wait(ctx context.Context, duration time.Duration) bool {
+	if checker.IsLessThanOrEqual(duration, 0) {
+		return checker.IsNil(ctx.Err())
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
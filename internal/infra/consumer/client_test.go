@@ -0,0 +1,154 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consumer
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/log"
+)
+
+// consumerTestEnv returns the broker informed by the env, skipping the integration test otherwise.
+func consumerTestEnv(t *testing.T, key string) string {
+	t.Helper()
+
+	value := os.Getenv(key)
+	if value == "" {
+		t.Skipf("%s not defined", key)
+	}
+	return value
+}
+
+func newConsumerTestConfig(broker enum.BackendBroker, path, group string, maxAttempts int, backoff time.Duration,
+	deadLetter string) *vo.ConsumerConfig {
+	endpoint := vo.NewEndpointConfigStatic("/orders", http.MethodPost)
+	consumer := vo.NewConsumerConfig("orders", broker, path, group, &endpoint, maxAttempts, vo.NewDuration(backoff),
+		deadLetter)
+	return &consumer
+}
+
+// consumerTestHandler acknowledges each message once it was received the times informed in acks, the messages not
+// informed are never acknowledged.
+type consumerTestHandler struct {
+	mutex    sync.Mutex
+	acks     map[string]int
+	received map[string]int
+}
+
+func newConsumerTestHandler(acks map[string]int) *consumerTestHandler {
+	return &consumerTestHandler{
+		acks:     acks,
+		received: map[string]int{},
+	}
+}
+
+func (h *consumerTestHandler) handle(_ context.Context, request *vo.EndpointRequest) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	body := string(request.Payload().RawBytes())
+	h.received[body]++
+
+	attempts, ok := h.acks[body]
+	return ok && h.received[body] >= attempts
+}
+
+func (h *consumerTestHandler) count(body string) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.received[body]
+}
+
+// waitConsumerTest polls the condition until it holds or the timeout elapses.
+func waitConsumerTest(timeout time.Duration, condition func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return condition()
+}
+
+func TestClientConsumeNotConfigured(t *testing.T) {
+	consumerClient := NewClient(nil, nil, log.NewBoot())
+
+	for _, broker := range []enum.BackendBroker{enum.BackendBrokerAwsSqs, enum.BackendBrokerKafka,
+		enum.BackendBrokerNATS} {
+		consumer := newConsumerTestConfig(broker, "orders", "gopen", 0, 0, "")
+		if err := consumerClient.Consume(context.Background(), consumer, nil); err == nil {
+			t.Errorf("Consume() broker %s error = nil, want not configured", broker)
+		}
+	}
+}
+
+func TestClientBuildRequest(t *testing.T) {
+	consumer := newConsumerTestConfig(enum.BackendBrokerKafka, "orders", "gopen", 0, 0, "")
+	span := trace.SpanFromContext(context.Background())
+	header := http.Header{"X-Tenant": {"acme"}}
+
+	tests := []struct {
+		name            string
+		body            string
+		wantContentType string
+	}{
+		{"json", `{"id":1}`, vo.NewContentTypeJSON().String()},
+		{"text", "order 1", vo.NewContentTypeTextPlain().String()},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := client{}.buildRequest(consumer, span, header, []byte(tt.body))
+			if request.Route() != "orders" || request.Operation() != http.MethodPost ||
+				request.Metadata().GetFirst("X-Tenant") != "acme" {
+				t.Errorf("buildRequest() = route %q operation %q, want orders POST with the header",
+					request.Route(), request.Operation())
+			}
+
+			var contentType string
+			if request.HasPayload() {
+				contentType = request.Payload().ContentType().String()
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("buildRequest() content type = %q, want %q", contentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestClientWait(t *testing.T) {
+	if !(client{}).wait(context.Background(), time.Millisecond) {
+		t.Errorf("wait() = false, want true")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if (client{}).wait(ctx, time.Minute) || (client{}).wait(ctx, 0) {
+		t.Errorf("wait() = true with ctx done, want false")
+	}
+}
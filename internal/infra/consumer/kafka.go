@@ -0,0 +1,145 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package consumer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// consumeKafka joins the consumer group and commits each record once handled. Records can not be returned to the
+// topic, so the retries happen in process and, when exhausted, the record goes to the dead letter topic. When a record
+// is not handled, its partition is rewound to it while the other partitions of the fetch keep being consumed.
+func (c client) consumeKafka(ctx context.Context, consumer *vo.ConsumerConfig, handler app.ConsumerHandler) error {
+	if checker.IsNil(c.kafka) {
+		return errors.Newf("consumer failed: id=%s broker=%s reason=not configured", consumer.ID(),
+			consumer.Broker())
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(c.kafka.Addresses...),
+		kgo.ConsumerGroup(consumer.Group()),
+		kgo.ConsumeTopics(consumer.Path()),
+		kgo.DisableAutoCommit(),
+	}
+	if checker.IsNotEmpty(c.kafka.ClientID) {
+		opts = append(opts, kgo.ClientID(c.kafka.ClientID))
+	}
+
+	kafkaClient, err := kgo.NewClient(opts...)
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "consumer failed: op=new-kafka-client id=%s", consumer.ID())
+	}
+	defer kafkaClient.Close()
+
+	for checker.IsNil(ctx.Err()) {
+		fetches := kafkaClient.PollFetches(ctx)
+		if fetches.IsClientClosed() || checker.NonNil(ctx.Err()) {
+			break
+		}
+		fetches.EachError(func(topic string, partition int32, err error) {
+			c.log.PrintWarnf("Consumer %s error fetching topic=%s partition=%d: %s", consumer.ID(), topic,
+				partition, err)
+		})
+
+		var handled []*kgo.Record
+		rewinds := map[string]map[int32]kgo.EpochOffset{}
+		fetches.EachPartition(func(partition kgo.FetchTopicPartition) {
+			if _, rewound := rewinds[partition.Topic][partition.Partition]; rewound {
+				return
+			}
+			for _, record := range partition.Records {
+				if checker.NonNil(ctx.Err()) || !c.handleKafkaRecord(ctx, kafkaClient, consumer, handler, record) {
+					c.addKafkaRewind(rewinds, record)
+					return
+				}
+				handled = append(handled, record)
+			}
+		})
+
+		if checker.IsNotEmpty(handled) {
+			if err = kafkaClient.CommitRecords(context.WithoutCancel(ctx), handled...); checker.NonNil(err) {
+				c.log.PrintWarnf("Consumer %s error committing records: %s", consumer.ID(), err)
+			}
+		}
+		if checker.IsNotEmpty(rewinds) {
+			kafkaClient.SetOffsets(rewinds)
+		}
+	}
+	return nil
+}
+
+// handleKafkaRecord returns false when ctx is done during the retries or the record could not be produced to the dead
+// letter, leaving the record to be received again.
+func (c client) handleKafkaRecord(
+	ctx context.Context,
+	kafkaClient *kgo.Client,
+	consumer *vo.ConsumerConfig,
+	handler app.ConsumerHandler,
+	record *kgo.Record,
+) bool {
+	spanCtx, span := c.startSpan(context.WithoutCancel(ctx), consumer, "kafka")
+	defer span.End()
+
+	for attempt := 1; ; attempt++ {
+		if handler(spanCtx, c.buildRequest(consumer, span, c.buildKafkaHeader(record), record.Value)) {
+			return true
+		} else if checker.IsGreaterThanOrEqual(attempt, consumer.MaxAttempts()) {
+			break
+		} else if !c.wait(ctx, consumer.Backoff().Time()) {
+			return false
+		}
+	}
+
+	if consumer.HasDeadLetter() {
+		deadLetter := &kgo.Record{
+			Topic:   consumer.DeadLetter(),
+			Key:     record.Key,
+			Value:   record.Value,
+			Headers: record.Headers,
+		}
+		if err := kafkaClient.ProduceSync(spanCtx, deadLetter).FirstErr(); checker.NonNil(err) {
+			c.log.PrintWarnf("Consumer %s error producing record to dead letter: %s", consumer.ID(), err)
+			return false
+		}
+	}
+	return true
+}
+
+// addKafkaRewind stops the partition of the record at its offset, the next polls receive it again instead of the
+// records after it, which would move the committed offset beyond the record not handled.
+func (c client) addKafkaRewind(rewinds map[string]map[int32]kgo.EpochOffset, record *kgo.Record) {
+	if checker.IsNil(rewinds[record.Topic]) {
+		rewinds[record.Topic] = map[int32]kgo.EpochOffset{}
+	}
+	rewinds[record.Topic][record.Partition] = kgo.EpochOffset{Epoch: record.LeaderEpoch, Offset: record.Offset}
+}
+
+func (c client) buildKafkaHeader(record *kgo.Record) http.Header {
+	header := http.Header{}
+	for _, recordHeader := range record.Headers {
+		header.Add(recordHeader.Key, string(recordHeader.Value))
+	}
+	return header
+}
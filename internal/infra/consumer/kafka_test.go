@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consumer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/infra/log"
+)
+
+func TestClientAddKafkaRewind(t *testing.T) {
+	rewinds := map[string]map[int32]kgo.EpochOffset{}
+	client{}.addKafkaRewind(rewinds, &kgo.Record{Topic: "orders", Partition: 2, LeaderEpoch: 1, Offset: 10})
+
+	if got := rewinds["orders"][2]; got != (kgo.EpochOffset{Epoch: 1, Offset: 10}) {
+		t.Errorf("addKafkaRewind() = %+v, want epoch 1 offset 10", got)
+	}
+}
+
+// TestClientConsumeKafka produces to new topics, so the broker informed by GOPEN_TEST_KAFKA_ADDRESSES must allow
+// their automatic creation. The first record is acknowledged at the second attempt, the second one is never and goes
+// to the dead letter, and nothing is received again by the group after the commit.
+func TestClientConsumeKafka(t *testing.T) {
+	addresses := strings.Split(consumerTestEnv(t, "GOPEN_TEST_KAFKA_ADDRESSES"), ",")
+
+	topic := "gopen-test-" + uuid.New().String()
+	deadLetter := topic + "-dlq"
+	group := "gopen-test-" + uuid.New().String()
+
+	producer, err := kgo.NewClient(kgo.SeedBrokers(addresses...), kgo.AllowAutoTopicCreation())
+	if err != nil {
+		t.Fatalf("kgo.NewClient() error = %v", err)
+	}
+	defer producer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the dead letter topic is created by its first record, as the consumer client does not create topics
+	err = producer.ProduceSync(ctx, &kgo.Record{Topic: topic, Value: []byte("retried")},
+		&kgo.Record{Topic: topic, Value: []byte("dead")}, &kgo.Record{Topic: deadLetter, Value: []byte("init")},
+	).FirstErr()
+	if err != nil {
+		t.Fatalf("ProduceSync() error = %v", err)
+	}
+
+	consumerClient := NewClient(nil, &KafkaConfig{Addresses: addresses}, log.NewBoot())
+	consumer := newConsumerTestConfig(enum.BackendBrokerKafka, topic, group, 2, 0, deadLetter)
+	handler := newConsumerTestHandler(map[string]int{"retried": 2})
+
+	consumeCtx, stop := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() { done <- consumerClient.Consume(consumeCtx, consumer, handler.handle) }()
+
+	received := waitConsumerTest(30*time.Second, func() bool {
+		return handler.count("retried") == 2 && handler.count("dead") == 2
+	})
+	time.Sleep(time.Second)
+	stop()
+	if err = <-done; err != nil {
+		t.Fatalf("Consume() error = %v, want nil", err)
+	} else if !received {
+		t.Fatalf("Consume() received retried=%d dead=%d, want 2 each", handler.count("retried"),
+			handler.count("dead"))
+	}
+
+	deadLetterConsumer, err := kgo.NewClient(kgo.SeedBrokers(addresses...), kgo.ConsumeTopics(deadLetter))
+	if err != nil {
+		t.Fatalf("kgo.NewClient() error = %v", err)
+	}
+	defer deadLetterConsumer.Close()
+
+	var deadLetters []string
+	for len(deadLetters) < 2 && ctx.Err() == nil {
+		deadLetterConsumer.PollFetches(ctx).EachRecord(func(record *kgo.Record) {
+			deadLetters = append(deadLetters, string(record.Value))
+		})
+	}
+	if len(deadLetters) != 2 || deadLetters[1] != "dead" {
+		t.Errorf("dead letter records = %v, want [init dead]", deadLetters)
+	}
+
+	again := newConsumerTestHandler(nil)
+	consumeCtx, stop = context.WithTimeout(ctx, 5*time.Second)
+	defer stop()
+	if err = consumerClient.Consume(consumeCtx, consumer, again.handle); err != nil {
+		t.Fatalf("Consume() error = %v, want nil", err)
+	} else if again.count("retried") != 0 || again.count("dead") != 0 {
+		t.Errorf("Consume() received again retried=%d dead=%d, want 0 after the commit", again.count("retried"),
+			again.count("dead"))
+	}
+}
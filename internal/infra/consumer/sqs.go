@@ -0,0 +1,136 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package consumer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/converter"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+func (c client) consumeSQS(ctx context.Context, consumer *vo.ConsumerConfig, handler app.ConsumerHandler) error {
+	if checker.IsNil(c.sqs) {
+		return errors.Newf("consumer failed: id=%s broker=%s reason=not configured", consumer.ID(),
+			consumer.Broker())
+	}
+
+	for checker.IsNil(ctx.Err()) {
+		out, err := c.sqs.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              converter.ToPointer(consumer.Path()),
+			MaxNumberOfMessages:   10,
+			WaitTimeSeconds:       20,
+			MessageAttributeNames: []string{"All"},
+			MessageSystemAttributeNames: []sqsTypes.MessageSystemAttributeName{
+				sqsTypes.MessageSystemAttributeNameApproximateReceiveCount,
+			},
+		})
+		if checker.NonNil(err) {
+			if checker.NonNil(ctx.Err()) {
+				break
+			}
+			c.log.PrintWarnf("Consumer %s error receiving messages: %s", consumer.ID(), err)
+			c.wait(ctx, receiveErrorBackoff)
+			continue
+		}
+
+		for _, message := range out.Messages {
+			c.handleSQSMessage(context.WithoutCancel(ctx), consumer, handler, message)
+		}
+	}
+	return nil
+}
+
+// handleSQSMessage deletes the message when acknowledged. Otherwise, it returns to the queue after the backoff, until
+// max-attempts receives, then it is sent to the dead letter. Without max-attempts the queue redrive policy applies.
+func (c client) handleSQSMessage(
+	ctx context.Context,
+	consumer *vo.ConsumerConfig,
+	handler app.ConsumerHandler,
+	message sqsTypes.Message,
+) {
+	spanCtx, span := c.startSpan(ctx, consumer, "aws_sqs")
+	defer span.End()
+
+	var body []byte
+	if checker.NonNil(message.Body) {
+		body = converter.ToBytes(*message.Body)
+	}
+
+	if handler(spanCtx, c.buildRequest(consumer, span, c.buildSQSHeader(message), body)) {
+		c.deleteSQSMessage(ctx, consumer, message)
+		return
+	}
+
+	receiveCount := converter.ToInt(message.Attributes[string(
+		sqsTypes.MessageSystemAttributeNameApproximateReceiveCount)])
+	if consumer.HasMaxAttempts() && checker.IsGreaterThanOrEqual(receiveCount, consumer.MaxAttempts()) {
+		if consumer.HasDeadLetter() {
+			_, err := c.sqs.SendMessage(ctx, &sqs.SendMessageInput{
+				QueueUrl:          converter.ToPointer(consumer.DeadLetter()),
+				MessageBody:       message.Body,
+				MessageAttributes: message.MessageAttributes,
+			})
+			if checker.NonNil(err) {
+				c.log.PrintWarnf("Consumer %s error sending message to dead letter: %s", consumer.ID(), err)
+				return
+			}
+		}
+		c.deleteSQSMessage(ctx, consumer, message)
+		return
+	}
+
+	if checker.IsGreaterThan(consumer.Backoff().Time().Seconds(), 0) {
+		_, err := c.sqs.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          converter.ToPointer(consumer.Path()),
+			ReceiptHandle:     message.ReceiptHandle,
+			VisibilityTimeout: int32(consumer.Backoff().Time().Seconds()),
+		})
+		if checker.NonNil(err) {
+			c.log.PrintWarnf("Consumer %s error changing message visibility: %s", consumer.ID(), err)
+		}
+	}
+}
+
+func (c client) deleteSQSMessage(ctx context.Context, consumer *vo.ConsumerConfig, message sqsTypes.Message) {
+	_, err := c.sqs.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      converter.ToPointer(consumer.Path()),
+		ReceiptHandle: message.ReceiptHandle,
+	})
+	if checker.NonNil(err) {
+		c.log.PrintWarnf("Consumer %s error deleting message: %s", consumer.ID(), err)
+	}
+}
+
+func (c client) buildSQSHeader(message sqsTypes.Message) http.Header {
+	header := http.Header{}
+	for key, attribute := range message.MessageAttributes {
+		if checker.NonNil(attribute.StringValue) {
+			header.Add(key, *attribute.StringValue)
+		} else if checker.NonNil(attribute.BinaryValue) {
+			header.Add(key, string(attribute.BinaryValue))
+		}
+	}
+	return header
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/google/uuid"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/infra/log"
+)
+
+// newSQSTestQueue creates a queue removed at the end of the test.
+func newSQSTestQueue(t *testing.T, ctx context.Context, sqsClient *sqs.Client) string {
+	t.Helper()
+
+	out, err := sqsClient.CreateQueue(ctx, &sqs.CreateQueueInput{
+		QueueName: aws.String("gopen-test-" + uuid.NewString()),
+	})
+	if err != nil {
+		t.Fatalf("CreateQueue() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = sqsClient.DeleteQueue(context.Background(), &sqs.DeleteQueueInput{QueueUrl: out.QueueUrl})
+	})
+	return *out.QueueUrl
+}
+
+// TestClientConsumeSQS runs against the SQS compatible endpoint informed by GOPEN_TEST_SQS_ENDPOINT, with the
+// credentials and region of the AWS env. The first message is acknowledged at once, the second one never and goes to
+// the dead letter after two receives.
+func TestClientConsumeSQS(t *testing.T) {
+	endpoint := consumerTestEnv(t, "GOPEN_TEST_SQS_ENDPOINT")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		t.Fatalf("LoadDefaultConfig() error = %v", err)
+	}
+	sqsClient := sqs.NewFromConfig(awsConfig, func(o *sqs.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+
+	queue := newSQSTestQueue(t, ctx, sqsClient)
+	deadLetter := newSQSTestQueue(t, ctx, sqsClient)
+
+	for _, body := range []string{"acked", "dead"} {
+		_, err = sqsClient.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:    aws.String(queue),
+			MessageBody: aws.String(body),
+		})
+		if err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+	}
+
+	consumer := newConsumerTestConfig(enum.BackendBrokerAwsSqs, queue, "", 2, time.Second, deadLetter)
+	handler := newConsumerTestHandler(map[string]int{"acked": 1})
+
+	consumeCtx, stop := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() { done <- NewClient(sqsClient, nil, log.NewBoot()).Consume(consumeCtx, consumer, handler.handle) }()
+
+	received := waitConsumerTest(30*time.Second, func() bool {
+		return handler.count("acked") == 1 && handler.count("dead") == 2
+	})
+	stop()
+	if err = <-done; err != nil {
+		t.Fatalf("Consume() error = %v, want nil", err)
+	} else if !received {
+		t.Fatalf("Consume() received acked=%d dead=%d, want 1 and 2", handler.count("acked"), handler.count("dead"))
+	}
+
+	out, err := sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:        aws.String(deadLetter),
+		WaitTimeSeconds: 5,
+	})
+	if err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	} else if len(out.Messages) != 1 || *out.Messages[0].Body != "dead" {
+		t.Errorf("ReceiveMessage() dead letter = %d messages, want the dead one", len(out.Messages))
+	}
+
+	out, err = sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:        aws.String(queue),
+		WaitTimeSeconds: 2,
+	})
+	if err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	} else if len(out.Messages) != 0 {
+		t.Errorf("ReceiveMessage() queue = %d messages, want every message deleted", len(out.Messages))
+	}
+}
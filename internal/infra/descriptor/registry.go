@@ -0,0 +1,113 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+)
+
+// Registry keeps the descriptor sets read by path, shared by the gRPC router and client so that each file is read
+// once.
+type Registry struct {
+	mutex sync.RWMutex
+	files map[string]*protoregistry.Files
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		files: map[string]*protoregistry.Files{},
+	}
+}
+
+// FindUnaryMethod looks for the method of the service in the descriptor sets, in order, failing when it is not found
+// or when it streams.
+func (r *Registry) FindUnaryMethod(descriptors []string, service, method string,
+) (protoreflect.MethodDescriptor, error) {
+	for _, path := range descriptors {
+		files, err := r.load(path)
+		if checker.NonNil(err) {
+			return nil, err
+		}
+
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(service))
+		if checker.NonNil(err) {
+			continue
+		}
+
+		serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+
+		methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(method))
+		if checker.IsNil(methodDescriptor) {
+			continue
+		} else if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+			return nil, errors.Newf("descriptor failed: op=find-method method=/%s/%s reason=only unary methods are "+
+				"supported", service, method)
+		}
+		return methodDescriptor, nil
+	}
+	return nil, errors.Newf("descriptor failed: op=find-method method=/%s/%s reason=not found in descriptors",
+		service, method)
+}
+
+// load reads a descriptor set generated with protoc --include_imports --descriptor_set_out, so that every dependency
+// of the services is available to resolve the messages.
+func (r *Registry) load(path string) (*protoregistry.Files, error) {
+	r.mutex.RLock()
+	files, ok := r.files[path]
+	r.mutex.RUnlock()
+	if ok {
+		return files, nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if files, ok = r.files[path]; ok {
+		return files, nil
+	}
+
+	descriptorBytes, err := os.ReadFile(path)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "descriptor failed: op=read path=%s", path)
+	}
+
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(descriptorBytes, &descriptorSet); checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "descriptor failed: op=unmarshal path=%s", path)
+	}
+
+	files, err = protodesc.NewFiles(&descriptorSet)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "descriptor failed: op=build path=%s", path)
+	}
+
+	r.files[path] = files
+	return files, nil
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	netgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/app/model/rpc"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/descriptor"
+)
+
+var marshalOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+type client struct {
+	mutex       sync.Mutex
+	conns       map[string]*netgrpc.ClientConn
+	descriptors *descriptor.Registry
+}
+
+// NewClient creates a gRPC client that keeps one connection per host, opened on the first call. The messages are
+// built at runtime from the descriptor sets, so no generated code is needed.
+func NewClient(descriptors *descriptor.Registry) app.GRPCClient {
+	return &client{
+		conns:       map[string]*netgrpc.ClientConn{},
+		descriptors: descriptors,
+	}
+}
+
+// Load resolves the method of the backend in its descriptor sets, so that a bad configuration fails the boot instead
+// of the calls.
+func (c *client) Load(backend *vo.BackendGRPCConfig) error {
+	_, err := c.descriptors.FindUnaryMethod(backend.Descriptors(), backend.Service(), backend.Method())
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "grpc failed: op=load method=%s", backend.FullMethod())
+	}
+	return nil
+}
+
+// Close closes the connections opened to the hosts.
+func (c *client) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for host, conn := range c.conns {
+		_ = conn.Close()
+		delete(c.conns, host)
+	}
+}
+
+func (c *client) Invoke(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest,
+	request *vo.GRPCBackendRequest) (*rpc.Response, error) {
+	method, err := c.descriptors.FindUnaryMethod(request.Descriptors(), request.Service(), request.Method())
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	input, err := c.buildInput(method, request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	conn, err := c.conn(request.Host())
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	ctx = metadata.NewOutgoingContext(ctx, c.buildMetadata(endpoint.RequestClient(), parent, request))
+
+	var header, trailer metadata.MD
+	output := dynamicpb.NewMessage(method.Output())
+
+	err = conn.Invoke(ctx, request.FullMethod(), input, output, netgrpc.Header(&header), netgrpc.Trailer(&trailer))
+	if checker.NonNil(ctx.Err()) {
+		return nil, ctx.Err()
+	} else if checker.NonNil(err) {
+		grpcStatus, ok := status.FromError(err)
+		if !ok {
+			return nil, errors.Inheritf(err, "grpc failed: op=invoke host=%s method=%s", request.Host(),
+				request.FullMethod())
+		}
+		return &rpc.Response{
+			Code:    grpcStatus.Code(),
+			Message: grpcStatus.Message(),
+			Header:  c.buildHeader(header, trailer),
+		}, nil
+	}
+
+	body, err := marshalOptions.Marshal(output)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "grpc failed: op=marshal message=%s", method.Output().FullName())
+	}
+
+	return &rpc.Response{
+		Code:   codes.OK,
+		Header: c.buildHeader(header, trailer),
+		Body:   body,
+	}, nil
+}
+
+func (c *client) buildInput(method protoreflect.MethodDescriptor, request *vo.GRPCBackendRequest,
+) (*dynamicpb.Message, error) {
+	input := dynamicpb.NewMessage(method.Input())
+	if !request.HasPayload() {
+		return input, nil
+	}
+
+	payloadBytes, err := request.Payload().Bytes()
+	if checker.NonNil(err) {
+		return nil, err
+	} else if checker.IsEmpty(payloadBytes) {
+		return input, nil
+	}
+
+	if err = unmarshalOptions.Unmarshal(payloadBytes, input); checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "grpc failed: op=unmarshal message=%s", method.Input().FullName())
+	}
+	return input, nil
+}
+
+// buildMetadata converts the header into the call metadata, dropping the keys controlled by the gRPC protocol.
+func (c *client) buildMetadata(clientCfg *vo.RequestClientConfig, parent *vo.EndpointRequest,
+	request *vo.GRPCBackendRequest) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range request.Metadata().Copy() {
+		key = strings.ToLower(key)
+		if c.isReservedKey(key) {
+			continue
+		}
+		md.Append(key, values...)
+	}
+
+	if checker.NonNil(clientCfg) && clientCfg.IP().HasPropagateRequest() {
+		md.Set(strings.ToLower(clientCfg.IP().Propagate().Request()), parent.ClientIP())
+	}
+	if checker.NonNil(clientCfg) && checker.NonNil(clientCfg.RequestID()) && clientCfg.RequestID().HasPropagateRequest() {
+		md.Set(strings.ToLower(clientCfg.RequestID().Propagate().Request()), parent.ID())
+	}
+
+	return md
+}
+
+// buildHeader joins the header and trailer metadata received, as a single header of the backend response.
+func (c *client) buildHeader(header, trailer metadata.MD) map[string][]string {
+	result := map[string][]string{}
+	for _, md := range []metadata.MD{header, trailer} {
+		for key, values := range md {
+			if c.isReservedKey(key) {
+				continue
+			}
+			result[key] = append(result[key], values...)
+		}
+	}
+	return result
+}
+
+func (c *client) isReservedKey(key string) bool {
+	if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") {
+		return true
+	}
+	switch key {
+	case "content-type", "content-length", "te", "host", "connection", "user-agent":
+		return true
+	}
+	return false
+}
+
+// conn returns the connection of the host, the "grpcs://" and "https://" schemes enable TLS, any other host is
+// dialed in plaintext.
+func (c *client) conn(host string) (*netgrpc.ClientConn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if conn, ok := c.conns[host]; ok {
+		return conn, nil
+	}
+
+	target := host
+	transportCredentials := insecure.NewCredentials()
+	for _, scheme := range []string{"grpcs://", "https://"} {
+		if strings.HasPrefix(host, scheme) {
+			target = strings.TrimPrefix(host, scheme)
+			transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+	}
+	for _, scheme := range []string{"grpc://", "http://"} {
+		target = strings.TrimPrefix(target, scheme)
+	}
+
+	conn, err := netgrpc.NewClient(target,
+		netgrpc.WithTransportCredentials(transportCredentials),
+		netgrpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "grpc failed: op=dial host=%s", host)
+	}
+
+	c.conns[host] = conn
+	return conn, nil
+}
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	"github.com/tech4works/checker"
@@ -35,16 +36,18 @@ import (
 )
 
 type client struct {
-	engine   *http.Client
-	breakers sync.Map
-	cfg      *vo.ClientConfig
-	log      app.BootLog
+	engine    *http.Client
+	breakers  sync.Map
+	cfg       *vo.ClientConfig
+	awsConfig aws.Config
+	log       app.BootLog
 }
 
 // NewClient creates an HTTP client with optimized transport, circuit breaker per host,
 // and retry for GET/HEAD on transient errors. All settings are derived from the
-// server.client JSON config block (populated via env vars).
-func NewClient(gopen *dto.Gopen, log app.BootLog) app.HTTPClient {
+// server.client JSON config block (populated via env vars). The AWS config provides the credentials of the backends
+// signed with SigV4.
+func NewClient(gopen *dto.Gopen, awsConfig aws.Config, log app.BootLog) app.HTTPClient {
 	cfg := buildClientConfig(gopen)
 
 	transport := &http.Transport{
@@ -63,13 +66,20 @@ func NewClient(gopen *dto.Gopen, log app.BootLog) app.HTTPClient {
 	log.PrintInfof("HTTP client retry: max-retries=%d backoff=%s",
 		cfg.RetryMaxRetries(), cfg.RetryBackoff())
 
+	var roundTripper http.RoundTripper = transport
+	if checker.IsNotEmpty(cfg.TLS()) {
+		roundTripper = newHostTransport(transport, cfg.TLS())
+		log.PrintInfof("HTTP client tls: hosts=%d", len(cfg.TLS()))
+	}
+
 	return &client{
 		engine: &http.Client{
-			Transport: otelhttp.NewTransport(transport),
+			Transport: otelhttp.NewTransport(roundTripper),
 			Timeout:   cfg.Timeout(),
 		},
-		cfg: cfg,
-		log: log,
+		cfg:       cfg,
+		awsConfig: awsConfig,
+		log:       log,
 	}
 }
 
@@ -78,6 +88,7 @@ func buildClientConfig(gopen *dto.Gopen) *vo.ClientConfig {
 	var maxIdleConns, maxIdleConnsPerHost int
 	var cb vo.CircuitBreakerConfig
 	var retry vo.RetryConfig
+	var tls []vo.ClientTLSConfig
 
 	if checker.NonNil(gopen) && checker.NonNil(gopen.Server) && checker.NonNil(gopen.Server.Client) {
 		c := gopen.Server.Client
@@ -121,9 +132,12 @@ func buildClientConfig(gopen *dto.Gopen) *vo.ClientConfig {
 			}
 			retry = vo.NewRetryConfig(mr, b)
 		}
+		for _, t := range c.TLS {
+			tls = append(tls, vo.NewClientTLSConfig(t.Hosts, t.CertFile, t.KeyFile, t.CAFile, t.ServerName))
+		}
 	}
 
-	return vo.NewClientConfig(timeout, maxIdleConns, maxIdleConnsPerHost, idleConnTimeout, cb, retry)
+	return vo.NewClientConfig(timeout, maxIdleConns, maxIdleConnsPerHost, idleConnTimeout, cb, retry, tls)
 }
 
 func (c *client) MakeRequest(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest,
@@ -227,6 +241,11 @@ func (c *client) buildNetHTTPRequest(ctx context.Context, endpoint *vo.EndpointC
 	httpRequest.Header = c.buildNetHTTPRequestHeader(ctx, clientCfg, parent, request)
 	httpRequest.URL.RawQuery = request.Query().Encode()
 
+	err = c.signNetHTTPRequest(ctx, httpRequest, request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
 	return httpRequest, nil
 }
 
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+	"github.com/tech4works/gopen-gateway/internal/infra/canonical"
+)
+
+// signNetHTTPRequest runs after the header and the body are final, so that the signature covers exactly what is sent.
+func (c *client) signNetHTTPRequest(ctx context.Context, httpRequest *http.Request, request *vo.HTTPBackendRequest,
+) error {
+	if !request.HasSigning() {
+		return nil
+	}
+
+	var body []byte
+	if request.HasBody() {
+		body = request.Body().RawBytes()
+	}
+
+	signing := request.Signing()
+	if signing.HasHMAC() {
+		err := c.signHMAC(signing.HMAC(), httpRequest, body)
+		if checker.NonNil(err) {
+			return err
+		}
+	}
+	if signing.HasAWS() {
+		return c.signAWS(ctx, signing.AWS(), httpRequest, body)
+	}
+	return nil
+}
+
+func (c *client) signAWS(ctx context.Context, config *vo.BackendSigningAWSConfig, httpRequest *http.Request,
+	body []byte) error {
+	if checker.IsNil(c.awsConfig.Credentials) {
+		return errors.Newf("signing failed: op=aws-sigv4 credentials not configured")
+	}
+
+	credentials, err := c.awsConfig.Credentials.Retrieve(ctx)
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "signing failed: op=aws-sigv4 retrieve credentials")
+	}
+
+	region := c.awsConfig.Region
+	if config.HasRegion() {
+		region = config.Region()
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	httpRequest.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	err = v4.NewSigner().SignHTTP(ctx, credentials, httpRequest, payloadHash, config.Service(), region, time.Now())
+	if checker.NonNil(err) {
+		return errors.Inheritf(err, "signing failed: op=aws-sigv4 service=%s region=%s", config.Service(), region)
+	}
+	return nil
+}
+
+func (c *client) signHMAC(config *vo.BackendSigningHMACConfig, httpRequest *http.Request, body []byte) error {
+	secret := os.Getenv(config.SecretEnv())
+	if checker.IsEmpty(secret) {
+		return errors.Newf("signing failed: op=hmac env=%s not defined", config.SecretEnv())
+	}
+
+	var timestamp string
+	if config.HasTimestampHeader() {
+		timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+		httpRequest.Header.Set(config.TimestampHeader(), timestamp)
+	}
+
+	mac := hmac.New(canonical.HashFunc(config.Algorithm()), []byte(secret))
+	mac.Write(canonical.Build(config.Canonical(), map[string]string{
+		"method":    httpRequest.Method,
+		"path":      httpRequest.URL.EscapedPath(),
+		"query":     httpRequest.URL.RawQuery,
+		"timestamp": timestamp,
+		"body":      string(body),
+	}, httpRequest.Header.Get))
+
+	signature := hex.EncodeToString(mac.Sum(nil))
+	if checker.Equals(config.Encoding(), enum.SignatureEncodingBase64) {
+		signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	httpRequest.Header.Set(config.Header(), config.Prefix()+signature)
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// hostTransport routes each request to the transport of its backend host, so that client certificates and custom CAs
+// are only used on the connections that require them. The other hosts use the default transport.
+type hostTransport struct {
+	fallback http.RoundTripper
+	byHost   map[string]http.RoundTripper
+}
+
+func newHostTransport(base *http.Transport, configs []vo.ClientTLSConfig) http.RoundTripper {
+	byHost := map[string]http.RoundTripper{}
+	for _, config := range configs {
+		transport := base.Clone()
+		transport.TLSClientConfig = buildClientTLSConfig(config)
+		for _, host := range config.Hosts() {
+			byHost[host] = transport
+		}
+	}
+	return hostTransport{
+		fallback: base,
+		byHost:   byHost,
+	}
+}
+
+// RoundTrip looks up the host with the port first, falling back to the hostname only.
+func (h hostTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if transport, ok := h.byHost[request.URL.Host]; ok {
+		return transport.RoundTrip(request)
+	} else if transport, ok = h.byHost[request.URL.Hostname()]; ok {
+		return transport.RoundTrip(request)
+	}
+	return h.fallback.RoundTrip(request)
+}
+
+func buildClientTLSConfig(config vo.ClientTLSConfig) *tls.Config {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName(),
+	}
+
+	if config.HasCertificate() {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile(), config.KeyFile())
+		if checker.NonNil(err) {
+			panic(errors.Inheritf(err, "client tls failed: op=load-certificate cert-file=%s key-file=%s",
+				config.CertFile(), config.KeyFile()))
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if config.HasCAFile() {
+		pem, err := os.ReadFile(config.CAFile())
+		if checker.NonNil(err) {
+			panic(errors.Inheritf(err, "client tls failed: op=read-ca ca-file=%s", config.CAFile()))
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			panic(errors.Newf("client tls failed: op=parse-ca ca-file=%s no certificate found", config.CAFile()))
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig
+}
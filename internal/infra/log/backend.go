@@ -29,7 +29,7 @@ import (
 type backendLog struct {
 }
 
-// NewBackend cria uma instância de BackendLog para mensagens de backends HTTP e publisher.
+// NewBackend cria uma instância de BackendLog para mensagens de backends HTTP, publisher, gRPC e GraphQL.
 func NewBackend() app.BackendLog {
 	return backendLog{}
 }
@@ -88,6 +88,9 @@ func (b backendLog) PrintPublisherRequest(
 		if checker.NonNil(request.DeduplicationID()) {
 			text += fmt.Sprintf(" deduplication_id=%s", *request.DeduplicationID())
 		}
+		if checker.NonNil(request.RoutingKey()) {
+			text += fmt.Sprintf(" routing_key=%s", *request.RoutingKey())
+		}
 		if checker.IsGreaterThan(request.Delay().Time().Milliseconds(), 0) {
 			text += fmt.Sprintf(" delay=%dms", request.Delay().Time().Milliseconds())
 		}
@@ -103,6 +106,9 @@ func (b backendLog) PrintPublisherRequest(
 		if checker.NonNil(request.DeduplicationID()) {
 			text += fmt.Sprintf(" deduplication_id=%s", *request.DeduplicationID())
 		}
+		if checker.NonNil(request.RoutingKey()) {
+			text += fmt.Sprintf(" routing_key=%s", *request.RoutingKey())
+		}
 		if checker.IsGreaterThan(request.Delay().Time().Milliseconds(), 0) {
 			text += fmt.Sprintf(" delay=%dms", request.Delay().Time().Milliseconds())
 		}
@@ -110,6 +116,34 @@ func (b backendLog) PrintPublisherRequest(
 	}
 }
 
+func (b backendLog) PrintGRPCRequest(
+	executeData dto.ExecuteEndpoint,
+	backend *vo.BackendConfig,
+	request *vo.GRPCBackendRequest,
+) {
+	host := BuildURIText(request.Host())
+	method := request.FullMethod()
+
+	if DebugLevel.Allowed() {
+		metadata := request.Metadata().String()
+		payload := "<nil>"
+		if request.HasPayload() {
+			if s, err := request.Payload().CompactString(); err == nil {
+				payload = s
+			}
+		}
+		text := fmt.Sprintf("Backend gRPC request started host=%s method=%s metadata=%s payload=%s",
+			host, method, metadata, payload)
+		Print(DebugLevel, backend.Flow().Abbreviation(), b.prefix(executeData, backend), text)
+	} else {
+		text := fmt.Sprintf("Backend gRPC request started host=%s method=%s", host, method)
+		if request.HasPayload() {
+			text += fmt.Sprintf(" payload_size=%s", request.Payload().SizeInByteUnit())
+		}
+		Print(InfoLevel, backend.Flow().Abbreviation(), b.prefix(executeData, backend), text)
+	}
+}
+
 func (b backendLog) PrintResponse(
 	executeData dto.ExecuteEndpoint,
 	backend *vo.BackendConfig,
@@ -177,6 +211,8 @@ func (b backendLog) prefix(executeData dto.ExecuteEndpoint, backend *vo.BackendC
 		tintText = BuildTintText(backend.HTTP().Method())
 	} else if backend.IsPublisher() {
 		tintText = BuildTintText(backend.Publisher().Broker().String())
+	} else if backend.IsGRPC() || backend.IsGraphQL() {
+		tintText = BuildTintText(string(backend.Kind()))
 	}
 
 	return fmt.Sprintf("[%s | %s | %s |%s]", id, ip, traceID, tintText)
@@ -146,6 +146,7 @@ func emitOTel(ctx context.Context, lvl Level, tag, prefix, msgText string) {
 		"path":             "request.path",
 		"group_id":         "messaging.group_id",
 		"deduplication_id": "messaging.deduplication_id",
+		"routing_key":      "messaging.routing_key",
 		"delay":            "messaging.delay",
 	}
 
@@ -71,7 +71,9 @@ func tintTextStyle(s string) string {
 	switch s {
 	case http.MethodPost:
 		return BackgroundYellow
-	case http.MethodGet, enum.BackendBrokerAwsSns.String(), enum.BackendBrokerAwsSqs.String():
+	case http.MethodGet, enum.BackendBrokerAwsSns.String(), enum.BackendBrokerAwsSqs.String(),
+		enum.BackendBrokerKafka.String(), enum.BackendBrokerAMQP.String(),
+		enum.BackendBrokerNATS.String(), enum.BackendBrokerRedisStream.String():
 		return BackgroundBlue
 	case http.MethodDelete:
 		return BackgroundRed
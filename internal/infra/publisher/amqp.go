@@ -0,0 +1,80 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+)
+
+// AMQPConnection keeps a single channel in confirm mode shared by every AMQP backend. The connection is lazy and
+// reopened on the next publish after the broker closes it.
+type AMQPConnection struct {
+	url     string
+	mutex   sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+func NewAMQPConnection(url string) *AMQPConnection {
+	return &AMQPConnection{
+		url: url,
+	}
+}
+
+func (a *AMQPConnection) Channel() (*amqp.Channel, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if checker.NonNil(a.channel) && !a.channel.IsClosed() {
+		return a.channel, nil
+	}
+
+	if checker.IsNil(a.conn) || a.conn.IsClosed() {
+		conn, err := amqp.Dial(a.url)
+		if checker.NonNil(err) {
+			return nil, errors.Inheritf(err, "publish client: op=amqp-dial")
+		}
+		a.conn = conn
+	}
+
+	channel, err := a.conn.Channel()
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=amqp-open-channel")
+	}
+	if err = channel.Confirm(false); checker.NonNil(err) {
+		channel.Close()
+		return nil, errors.Inheritf(err, "publish client: op=amqp-confirm-channel")
+	}
+
+	a.channel = channel
+	return channel, nil
+}
+
+func (a *AMQPConnection) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if checker.IsNil(a.conn) || a.conn.IsClosed() {
+		return nil
+	}
+	return a.conn.Close()
+}
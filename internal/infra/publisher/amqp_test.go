@@ -0,0 +1,91 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+func TestClientBuildAMQPPublishing(t *testing.T) {
+	request := newPublisherTestRequest(enum.BackendBrokerAMQP, "orders", "", "order-1", "created")
+
+	msg, err := client{}.buildAMQPPublishing(newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("buildAMQPPublishing() error = %v, want nil", err)
+	} else if msg.MessageId != "order-1" || msg.DeliveryMode != amqp.Persistent || string(msg.Body) != `{"id":1}` {
+		t.Errorf("buildAMQPPublishing() = id %q mode %d body %q, want order-1 persistent {\"id\":1}", msg.MessageId,
+			msg.DeliveryMode, msg.Body)
+	} else if _, ok := msg.Headers["x-delay"]; ok {
+		t.Errorf("buildAMQPPublishing() x-delay informed without delay")
+	} else if msg.Headers["X-Tenant"] != "acme" {
+		t.Errorf("buildAMQPPublishing() headers = %v, want the attributes", msg.Headers)
+	}
+
+	delayed := vo.NewPublisherBackendRequest(vo.NewEmptyDegradation(), enum.BackendBrokerAMQP, "orders", "", "", "",
+		vo.NewDuration(5*time.Second), nil, nil)
+	msg, err = client{}.buildAMQPPublishing(newPublisherTestParent(), delayed)
+	if err != nil {
+		t.Fatalf("buildAMQPPublishing() error = %v, want nil", err)
+	} else if msg.Headers["x-delay"] != int64(5000) || msg.MessageId == "" {
+		t.Errorf("buildAMQPPublishing() = x-delay %v id %q, want 5000 and a generated id", msg.Headers["x-delay"],
+			msg.MessageId)
+	}
+}
+
+// TestClientPublishAMQP publishes to amq.direct with a temporary queue bound to the routing key, the broker is
+// informed by GOPEN_TEST_AMQP_URL.
+func TestClientPublishAMQP(t *testing.T) {
+	connection := NewAMQPConnection(publisherTestEnv(t, "GOPEN_TEST_AMQP_URL"))
+	defer connection.Close()
+
+	channel, err := connection.Channel()
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+	queue, err := channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		t.Fatalf("QueueDeclare() error = %v", err)
+	} else if err = channel.QueueBind(queue.Name, queue.Name, "amq.direct", false, nil); err != nil {
+		t.Fatalf("QueueBind() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := newPublisherTestRequest(enum.BackendBrokerAMQP, "amq.direct", "", "order-1", queue.Name)
+
+	response, err := NewClient(nil, nil, nil, connection, nil, nil).Publish(ctx, newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("Publish() error = %v, want nil", err)
+	} else if !response.OK || response.Body.MessageID != "order-1" || response.Body.RoutingKey != queue.Name {
+		t.Fatalf("Publish() = %+v, want acked with the message id and routing key", response.Body)
+	}
+
+	delivery, ok, err := channel.Get(queue.Name, true)
+	if err != nil || !ok {
+		t.Fatalf("Get() = %v, %v, want the message published", ok, err)
+	} else if delivery.MessageId != "order-1" || string(delivery.Body) != `{"id":1}` {
+		t.Errorf("Get() = id %q body %q, want order-1 {\"id\":1}", delivery.MessageId, delivery.Body)
+	}
+}
@@ -20,11 +20,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	snsTypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/twmb/franz-go/pkg/kgo"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -41,8 +48,12 @@ import (
 )
 
 type client struct {
-	sqs *sqs.Client
-	sns *sns.Client
+	sqs   *sqs.Client
+	sns   *sns.Client
+	kafka *kgo.Client
+	amqp  *AMQPConnection
+	nats  *NATSConnection
+	redis *RedisStream
 }
 
 type messageAttribute struct {
@@ -57,10 +68,21 @@ const (
 	dataTypeBinary = "Binary"
 )
 
-func NewClient(sqsClient *sqs.Client, snsClient *sns.Client) app.PublisherClient {
+func NewClient(
+	sqsClient *sqs.Client,
+	snsClient *sns.Client,
+	kafkaClient *kgo.Client,
+	amqpConnection *AMQPConnection,
+	natsConnection *NATSConnection,
+	redisStream *RedisStream,
+) app.PublisherClient {
 	return client{
-		sqs: sqsClient,
-		sns: snsClient,
+		sqs:   sqsClient,
+		sns:   snsClient,
+		kafka: kafkaClient,
+		amqp:  amqpConnection,
+		nats:  natsConnection,
+		redis: redisStream,
 	}
 }
 
@@ -83,6 +105,14 @@ func (c client) Publish(
 		resp, err = c.publishSQS(ctx, parent, request)
 	case enum.BackendBrokerAwsSns:
 		resp, err = c.publishSNS(ctx, parent, request)
+	case enum.BackendBrokerKafka:
+		resp, err = c.publishKafka(ctx, parent, request)
+	case enum.BackendBrokerAMQP:
+		resp, err = c.publishAMQP(ctx, parent, request)
+	case enum.BackendBrokerNATS:
+		resp, err = c.publishNATS(ctx, parent, request)
+	case enum.BackendBrokerRedisStream:
+		resp, err = c.publishRedisStream(ctx, parent, request)
 	default:
 		err = app.NewErrBackendBrokerNotImplemented(request.Broker().String())
 	}
@@ -140,6 +170,145 @@ func (c client) publishSNS(
 	return c.buildResponse(request, out.MessageId, out.SequenceNumber), nil
 }
 
+// publishKafka produces the record synchronously, the path is the topic and the group-id is the partition key, so
+// messages of the same group keep their order.
+func (c client) publishKafka(
+	ctx context.Context,
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*publisher.Response, error) {
+	if checker.IsNil(c.kafka) {
+		return nil, app.NewErrBackendBrokerNotConfigured(enum.BackendBrokerKafka.String())
+	}
+
+	record, err := c.buildKafkaRecord(parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=build-kafka-record")
+	}
+
+	out, err := c.kafka.ProduceSync(ctx, record).First()
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	response := c.buildResponse(request, converter.ToPointer(converter.ToString(out.Offset)), nil)
+	response.Body.Topic = out.Topic
+	response.Body.Partition = converter.ToPointer(out.Partition)
+	response.Body.Offset = converter.ToPointer(out.Offset)
+
+	return response, nil
+}
+
+// publishAMQP publishes to the exchange informed in the path and waits for the publisher confirm, a nack is answered
+// as a response not OK.
+func (c client) publishAMQP(
+	ctx context.Context,
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*publisher.Response, error) {
+	if checker.IsNil(c.amqp) {
+		return nil, app.NewErrBackendBrokerNotConfigured(enum.BackendBrokerAMQP.String())
+	}
+
+	channel, err := c.amqp.Channel()
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	msg, err := c.buildAMQPPublishing(parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=build-amqp-publishing")
+	}
+
+	var routingKey string
+	if request.HasRoutingKey() {
+		routingKey = *request.RoutingKey()
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, request.Path(), routingKey, false, false,
+		msg)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	ack, err := confirmation.WaitContext(ctx)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	response := c.buildResponse(request, converter.ToPointer(msg.MessageId), nil)
+	response.OK = ack
+	response.Body.RoutingKey = routingKey
+
+	return response, nil
+}
+
+// publishNATS publishes on the subject informed in the path. With JetStream the response carries the stream ack,
+// on core NATS the publish is only flushed to the server, as there is no acknowledgement.
+func (c client) publishNATS(
+	ctx context.Context,
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*publisher.Response, error) {
+	if checker.IsNil(c.nats) {
+		return nil, app.NewErrBackendBrokerNotConfigured(enum.BackendBrokerNATS.String())
+	}
+
+	msg, err := c.buildNATSMsg(parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=build-nats-msg")
+	}
+
+	messageID := uuid.New().String()
+	if request.HasDeduplicationID() {
+		messageID = *request.DeduplicationID()
+	}
+
+	if !c.nats.IsJetStream() {
+		if err = c.nats.conn.PublishMsg(msg); checker.NonNil(err) {
+			return nil, err
+		} else if err = c.nats.conn.FlushWithContext(ctx); checker.NonNil(err) {
+			return nil, err
+		}
+		return c.buildResponse(request, &messageID, nil), nil
+	}
+
+	ack, err := c.nats.jetStream.PublishMsg(ctx, msg)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	response := c.buildResponse(request, &messageID, converter.ToPointer(converter.ToString(ack.Sequence)))
+	response.Body.Stream = ack.Stream
+	response.Body.Duplicate = ack.Duplicate
+
+	return response, nil
+}
+
+// publishRedisStream appends the entry to the stream informed in the path, the ID generated by the server is returned
+// as the message id.
+func (c client) publishRedisStream(
+	ctx context.Context,
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*publisher.Response, error) {
+	if checker.IsNil(c.redis) {
+		return nil, app.NewErrBackendBrokerNotConfigured(enum.BackendBrokerRedisStream.String())
+	}
+
+	args, err := c.buildRedisXAddArgs(parent, request)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=build-redis-xadd-args")
+	}
+
+	id, err := c.redis.client.XAdd(ctx, args).Result()
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	return c.buildResponse(request, &id, nil), nil
+}
+
 func (c client) buildResponse(
 	request *vo.PublisherBackendRequest,
 	messageID *string,
@@ -206,6 +375,115 @@ func (c client) buildSNSPublishInput(
 	}, nil
 }
 
+func (c client) buildKafkaRecord(
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*kgo.Record, error) {
+	body, err := c.parseBodyToPointerString(request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	record := &kgo.Record{
+		Topic:   request.Path(),
+		Headers: c.toKafkaHeaders(c.buildMessageAttributes(parent, request)),
+	}
+	if checker.NonNil(body) {
+		record.Value = converter.ToBytes(*body)
+	}
+	if request.HasGroupID() {
+		record.Key = converter.ToBytes(*request.GroupID())
+	}
+
+	return record, nil
+}
+
+// buildAMQPPublishing uses the deduplication-id as message id when informed, and the x-delay header of the delayed
+// message exchange plugin to apply the delay.
+func (c client) buildAMQPPublishing(
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (amqp.Publishing, error) {
+	body, err := c.parseBodyToPointerString(request)
+	if checker.NonNil(err) {
+		return amqp.Publishing{}, err
+	}
+
+	headers := c.toAMQPHeaders(c.buildMessageAttributes(parent, request))
+	if checker.IsGreaterThan(request.Delay().Time().Milliseconds(), 0) {
+		headers["x-delay"] = request.Delay().Time().Milliseconds()
+	}
+
+	msg := amqp.Publishing{
+		Headers:      headers,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    uuid.New().String(),
+		Timestamp:    time.Now(),
+	}
+	if request.HasDeduplicationID() {
+		msg.MessageId = *request.DeduplicationID()
+	}
+	if checker.NonNil(body) {
+		msg.ContentType = request.Body().ContentType().String()
+		msg.Body = converter.ToBytes(*body)
+	}
+
+	return msg, nil
+}
+
+// buildNATSMsg informs the deduplication-id as Nats-Msg-Id, so JetStream discards duplicates within the stream
+// duplicate window.
+func (c client) buildNATSMsg(
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*nats.Msg, error) {
+	body, err := c.parseBodyToPointerString(request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	msg := nats.NewMsg(request.Path())
+	msg.Header = c.toNATSHeader(c.buildMessageAttributes(parent, request))
+	if request.HasDeduplicationID() {
+		msg.Header.Set(jetstream.MsgIDHeader, *request.DeduplicationID())
+	}
+	if checker.NonNil(body) {
+		msg.Data = converter.ToBytes(*body)
+	}
+
+	return msg, nil
+}
+
+// buildRedisXAddArgs writes the body in the "body" field and each attribute in a field of its own.
+func (c client) buildRedisXAddArgs(
+	parent *vo.EndpointRequest,
+	request *vo.PublisherBackendRequest,
+) (*redis.XAddArgs, error) {
+	body, err := c.parseBodyToPointerString(request)
+	if checker.NonNil(err) {
+		return nil, err
+	}
+
+	values := map[string]any{}
+	for key, attribute := range c.buildMessageAttributes(parent, request) {
+		if checker.Equals(attribute.dataType, dataTypeBinary) {
+			values[key] = attribute.binary
+		} else {
+			values[key] = attribute.value
+		}
+	}
+	if checker.NonNil(body) {
+		values["body"] = *body
+	}
+
+	return &redis.XAddArgs{
+		Stream: request.Path(),
+		MaxLen: c.redis.maxLen,
+		Approx: c.redis.approximate,
+		Values: values,
+	}, nil
+}
+
 func (c client) buildMessageAttributes(
 	parent *vo.EndpointRequest,
 	request *vo.PublisherBackendRequest,
@@ -262,6 +540,10 @@ func (c client) appendDefaultMessageAttributes(
 		dataType: dataTypeString,
 		value:    converter.ToString(request.DeduplicationIDDegraded()),
 	}
+	messageAttributes[app.XGopenRoutingKeyDegraded] = messageAttribute{
+		dataType: dataTypeString,
+		value:    converter.ToString(request.RoutingKeyDegraded()),
+	}
 	messageAttributes[app.XGopenAttributeDegraded] = messageAttribute{
 		dataType: dataTypeString,
 		value:    converter.ToString(request.AttributesDegraded()),
@@ -316,6 +598,52 @@ func (c client) toSNSMessageAttributes(
 	return out
 }
 
+func (c client) toKafkaHeaders(attributes map[string]messageAttribute) []kgo.RecordHeader {
+	out := make([]kgo.RecordHeader, 0, len(attributes))
+
+	for key, attribute := range attributes {
+		header := kgo.RecordHeader{Key: key}
+
+		if checker.Equals(attribute.dataType, dataTypeBinary) {
+			header.Value = attribute.binary
+		} else {
+			header.Value = converter.ToBytes(attribute.value)
+		}
+
+		out = append(out, header)
+	}
+
+	return out
+}
+
+func (c client) toAMQPHeaders(attributes map[string]messageAttribute) amqp.Table {
+	out := make(amqp.Table, len(attributes))
+
+	for key, attribute := range attributes {
+		if checker.Equals(attribute.dataType, dataTypeBinary) {
+			out[key] = attribute.binary
+		} else {
+			out[key] = attribute.value
+		}
+	}
+
+	return out
+}
+
+func (c client) toNATSHeader(attributes map[string]messageAttribute) nats.Header {
+	out := make(nats.Header, len(attributes))
+
+	for key, attribute := range attributes {
+		if checker.Equals(attribute.dataType, dataTypeBinary) {
+			out.Set(key, string(attribute.binary))
+		} else {
+			out.Set(key, attribute.value)
+		}
+	}
+
+	return out
+}
+
 func (c client) parseBodyToPointerString(request *vo.PublisherBackendRequest) (*string, error) {
 	if !request.HasBody() {
 		return nil, nil
@@ -348,9 +676,21 @@ func extractDestinationName(path string) string {
 func (c client) fillSpanAttributes(span trace.Span, request *vo.PublisherBackendRequest) {
 	system := "aws_sns"
 	destKey := "messaging.topic"
-	if request.Broker() == enum.BackendBrokerAwsSqs {
+	switch request.Broker() {
+	case enum.BackendBrokerAwsSqs:
 		system = "aws_sqs"
 		destKey = "messaging.queue"
+	case enum.BackendBrokerKafka:
+		system = "kafka"
+	case enum.BackendBrokerAMQP:
+		system = "rabbitmq"
+		destKey = "messaging.exchange"
+	case enum.BackendBrokerNATS:
+		system = "nats"
+		destKey = "messaging.subject"
+	case enum.BackendBrokerRedisStream:
+		system = "redis"
+		destKey = "messaging.stream"
 	}
 
 	span.SetAttributes(
@@ -369,6 +709,10 @@ func (c client) fillSpanAttributes(span trace.Span, request *vo.PublisherBackend
 		span.SetAttributes(attribute.String("messaging.message.deduplication_id", *request.DeduplicationID()))
 	}
 
+	if request.HasRoutingKey() {
+		span.SetAttributes(attribute.String("messaging.rabbitmq.destination.routing_key", *request.RoutingKey()))
+	}
+
 	if request.Delay().Time().Seconds() > 0 {
 		span.SetAttributes(attribute.Int("messaging.delay_seconds", int(request.Delay().Time().Seconds())))
 	}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+// publisherTestEnv returns the broker informed by the env, skipping the integration test otherwise.
+func publisherTestEnv(t *testing.T, key string) string {
+	t.Helper()
+
+	value := os.Getenv(key)
+	if value == "" {
+		t.Skipf("%s not defined", key)
+	}
+	return value
+}
+
+func newPublisherTestParent() *vo.EndpointRequest {
+	return vo.NewHTTPEndpointRequest("request-id", "trace-id", "10.0.0.1", "/orders", vo.NewURLPath("/orders", nil),
+		vo.NewEmptyQuery(), "POST", vo.NewEmptyMetadata(), nil, nil)
+}
+
+func newPublisherTestRequest(broker enum.BackendBroker, path, groupID, deduplicationID, routingKey string,
+) *vo.PublisherBackendRequest {
+	attributes := map[string]vo.AttributeValueConfig{
+		"X-Tenant": vo.NewAttributeValueConfig(enum.AttributeValueTypeString, "acme"),
+	}
+	return vo.NewPublisherBackendRequest(vo.NewEmptyDegradation(), broker, path, groupID, deduplicationID, routingKey,
+		vo.NewDuration(0), attributes, vo.NewPayloadJSON(bytes.NewBufferString(`{"id": 1}`)))
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+)
+
+// NewKafkaClient creates the producer shared by every KAFKA backend. The connection is lazy, so an unreachable
+// broker only fails when the first record is produced.
+func NewKafkaClient(addresses []string, clientID string) (*kgo.Client, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(addresses...),
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+	}
+	if checker.IsNotEmpty(clientID) {
+		opts = append(opts, kgo.ClientID(clientID))
+	}
+
+	kafkaClient, err := kgo.NewClient(opts...)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=new-kafka-client addresses=%v", addresses)
+	}
+	return kafkaClient, nil
+}
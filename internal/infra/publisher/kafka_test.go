@@ -0,0 +1,106 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+func TestClientBuildKafkaRecord(t *testing.T) {
+	request := newPublisherTestRequest(enum.BackendBrokerKafka, "orders", "customer-1", "", "")
+
+	record, err := client{}.buildKafkaRecord(newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("buildKafkaRecord() error = %v, want nil", err)
+	} else if record.Topic != "orders" || string(record.Key) != "customer-1" || string(record.Value) != `{"id":1}` {
+		t.Errorf("buildKafkaRecord() = topic %q key %q value %q, want orders customer-1 {\"id\":1}", record.Topic,
+			record.Key, record.Value)
+	}
+
+	headers := map[string]string{}
+	for _, header := range record.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	if headers["X-Tenant"] != "acme" || headers[app.XGopenRequestID] != "request-id" {
+		t.Errorf("buildKafkaRecord() headers = %v, want the attributes and the request id", headers)
+	}
+}
+
+func TestClientPublishKafkaNotConfigured(t *testing.T) {
+	request := newPublisherTestRequest(enum.BackendBrokerKafka, "orders", "", "", "")
+
+	_, err := client{}.Publish(context.Background(), newPublisherTestParent(), request)
+	if err == nil {
+		t.Errorf("Publish() error = nil, want not configured")
+	}
+}
+
+// TestClientPublishKafka produces to the topic informed by GOPEN_TEST_KAFKA_TOPIC, the broker must create it when
+// it does not exist.
+func TestClientPublishKafka(t *testing.T) {
+	addresses := strings.Split(publisherTestEnv(t, "GOPEN_TEST_KAFKA_ADDRESSES"), ",")
+	topic := publisherTestEnv(t, "GOPEN_TEST_KAFKA_TOPIC")
+
+	kafkaClient, err := NewKafkaClient(addresses, "gopen-test")
+	if err != nil {
+		t.Fatalf("NewKafkaClient() error = %v", err)
+	}
+	defer kafkaClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	groupID := uuid.New().String()
+	request := newPublisherTestRequest(enum.BackendBrokerKafka, topic, groupID, "", "")
+
+	response, err := NewClient(nil, nil, kafkaClient, nil, nil, nil).Publish(ctx, newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("Publish() error = %v, want nil", err)
+	} else if !response.OK || response.Body.Topic != topic || response.Body.Partition == nil ||
+		response.Body.Offset == nil {
+		t.Fatalf("Publish() = %+v, want OK with topic, partition and offset", response.Body)
+	}
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(addresses...),
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{
+			topic: {*response.Body.Partition: kgo.NewOffset().At(*response.Body.Offset)},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("kgo.NewClient() error = %v", err)
+	}
+	defer consumer.Close()
+
+	fetches := consumer.PollRecords(ctx, 1)
+	if errs := fetches.Errors(); len(errs) > 0 {
+		t.Fatalf("PollRecords() error = %v", errs[0].Err)
+	}
+	records := fetches.Records()
+	if len(records) != 1 || string(records[0].Key) != groupID || string(records[0].Value) != `{"id":1}` {
+		t.Errorf("PollRecords() = %v, want the record produced", records)
+	}
+}
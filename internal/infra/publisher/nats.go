@@ -0,0 +1,70 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+)
+
+// NATSConnection is shared by every NATS backend, when JetStream is enabled the messages are published to the
+// streams and acknowledged by the server, otherwise they are published on core NATS.
+type NATSConnection struct {
+	conn      *nats.Conn
+	jetStream jetstream.JetStream
+}
+
+// NewNATSConnection keeps retrying in background when the server is unreachable at boot, so the gateway starts
+// and the publishes fail until the connection is established.
+func NewNATSConnection(url, name string, jetStreamEnabled bool) (*NATSConnection, error) {
+	opts := []nats.Option{
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	}
+	if checker.IsNotEmpty(name) {
+		opts = append(opts, nats.Name(name))
+	}
+
+	conn, err := nats.Connect(url, opts...)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "publish client: op=nats-connect")
+	}
+
+	natsConnection := &NATSConnection{
+		conn: conn,
+	}
+	if jetStreamEnabled {
+		natsConnection.jetStream, err = jetstream.New(conn)
+		if checker.NonNil(err) {
+			conn.Close()
+			return nil, errors.Inheritf(err, "publish client: op=nats-jetstream")
+		}
+	}
+
+	return natsConnection, nil
+}
+
+func (n *NATSConnection) IsJetStream() bool {
+	return checker.NonNil(n.jetStream)
+}
+
+func (n *NATSConnection) Close() {
+	n.conn.Close()
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+func TestClientBuildNATSMsg(t *testing.T) {
+	request := newPublisherTestRequest(enum.BackendBrokerNATS, "orders.created", "", "order-1", "")
+
+	msg, err := client{}.buildNATSMsg(newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("buildNATSMsg() error = %v, want nil", err)
+	} else if msg.Subject != "orders.created" || string(msg.Data) != `{"id":1}` {
+		t.Errorf("buildNATSMsg() = subject %q data %q, want orders.created {\"id\":1}", msg.Subject, msg.Data)
+	} else if msg.Header.Get(jetstream.MsgIDHeader) != "order-1" || msg.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("buildNATSMsg() header = %v, want the message id and the attributes", msg.Header)
+	}
+}
+
+// TestClientPublishNATS publishes on core NATS to a subscribed subject, the server is informed by GOPEN_TEST_NATS_URL.
+func TestClientPublishNATS(t *testing.T) {
+	connection, err := NewNATSConnection(publisherTestEnv(t, "GOPEN_TEST_NATS_URL"), "gopen-test", false)
+	if err != nil {
+		t.Fatalf("NewNATSConnection() error = %v", err)
+	}
+	defer connection.Close()
+
+	subject := "gopen.test." + uuid.New().String()
+	subscription, err := connection.conn.SubscribeSync(subject)
+	if err != nil {
+		t.Fatalf("SubscribeSync() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := newPublisherTestRequest(enum.BackendBrokerNATS, subject, "", "", "")
+
+	response, err := NewClient(nil, nil, nil, nil, connection, nil).Publish(ctx, newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("Publish() error = %v, want nil", err)
+	} else if !response.OK || response.Body.MessageID == "" {
+		t.Fatalf("Publish() = %+v, want OK with a generated message id", response.Body)
+	}
+
+	msg, err := subscription.NextMsgWithContext(ctx)
+	if err != nil {
+		t.Fatalf("NextMsgWithContext() error = %v", err)
+	} else if string(msg.Data) != `{"id":1}` {
+		t.Errorf("NextMsgWithContext() data = %q, want {\"id\":1}", msg.Data)
+	}
+}
+
+// TestClientPublishNATSJetStream publishes twice with the same deduplication-id to a temporary stream, the second
+// publish is acknowledged as duplicate.
+func TestClientPublishNATSJetStream(t *testing.T) {
+	connection, err := NewNATSConnection(publisherTestEnv(t, "GOPEN_TEST_NATS_URL"), "gopen-test", true)
+	if err != nil {
+		t.Fatalf("NewNATSConnection() error = %v", err)
+	}
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	name := "GOPEN_TEST_" + uuid.New().String()[:8]
+	subject := "gopen.test." + name
+	_, err = connection.jetStream.CreateStream(ctx, jetstream.StreamConfig{Name: name, Subjects: []string{subject}})
+	if err != nil {
+		t.Fatalf("CreateStream() error = %v", err)
+	}
+	defer connection.jetStream.DeleteStream(context.Background(), name)
+
+	request := newPublisherTestRequest(enum.BackendBrokerNATS, subject, "", "order-1", "")
+	natsClient := NewClient(nil, nil, nil, nil, connection, nil)
+
+	for i, wantDuplicate := range []bool{false, true} {
+		response, err := natsClient.Publish(ctx, newPublisherTestParent(), request)
+		if err != nil {
+			t.Fatalf("Publish() %d error = %v, want nil", i+1, err)
+		} else if !response.OK || response.Body.Stream != name || response.Body.SequentialNumber != "1" ||
+			response.Body.Duplicate != wantDuplicate {
+			t.Errorf("Publish() %d = %+v, want stream %s sequence 1 duplicate %v", i+1, response.Body, name,
+				wantDuplicate)
+		}
+	}
+}
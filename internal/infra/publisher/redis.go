@@ -0,0 +1,37 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStream publishes using the client of the Redis declared as the global store, which is closed with the store.
+// maxLen trims the streams on every XADD and is disabled when zero.
+type RedisStream struct {
+	client      *redis.Client
+	maxLen      int64
+	approximate bool
+}
+
+func NewRedisStream(client *redis.Client, maxLen int64, approximate bool) *RedisStream {
+	return &RedisStream{
+		client:      client,
+		maxLen:      maxLen,
+		approximate: approximate,
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publisher
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+)
+
+func TestClientBuildRedisXAddArgs(t *testing.T) {
+	request := newPublisherTestRequest(enum.BackendBrokerRedisStream, "orders", "", "", "")
+	redisClient := client{redis: NewRedisStream(nil, 1000, true)}
+
+	args, err := redisClient.buildRedisXAddArgs(newPublisherTestParent(), request)
+	if err != nil {
+		t.Fatalf("buildRedisXAddArgs() error = %v, want nil", err)
+	} else if args.Stream != "orders" || args.MaxLen != 1000 || !args.Approx {
+		t.Errorf("buildRedisXAddArgs() = stream %q max len %d approx %v, want orders 1000 true", args.Stream,
+			args.MaxLen, args.Approx)
+	}
+
+	values := args.Values.(map[string]any)
+	if values["body"] != `{"id":1}` || values["X-Tenant"] != "acme" || values[app.XGopenRequestID] != "request-id" {
+		t.Errorf("buildRedisXAddArgs() values = %v, want the body and the attributes", values)
+	}
+}
+
+// TestClientPublishRedisStream appends to a temporary stream trimmed to a single entry, the server is informed by
+// GOPEN_TEST_REDIS_ADDRESS.
+func TestClientPublishRedisStream(t *testing.T) {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     publisherTestEnv(t, "GOPEN_TEST_REDIS_ADDRESS"),
+		Password: os.Getenv("GOPEN_TEST_REDIS_PASSWORD"),
+	})
+	defer redisClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream := "gopen:test:" + uuid.New().String()
+	defer redisClient.Del(context.Background(), stream)
+
+	request := newPublisherTestRequest(enum.BackendBrokerRedisStream, stream, "", "", "")
+	streamClient := NewClient(nil, nil, nil, nil, nil, NewRedisStream(redisClient, 1, false))
+
+	var lastID string
+	for i := 0; i < 2; i++ {
+		response, err := streamClient.Publish(ctx, newPublisherTestParent(), request)
+		if err != nil {
+			t.Fatalf("Publish() %d error = %v, want nil", i+1, err)
+		} else if !response.OK || response.Body.MessageID == "" {
+			t.Fatalf("Publish() %d = %+v, want OK with the entry id", i+1, response.Body)
+		}
+		lastID = response.Body.MessageID
+	}
+
+	entries, err := redisClient.XRange(ctx, stream, "-", "+").Result()
+	if err != nil {
+		t.Fatalf("XRange() error = %v", err)
+	} else if len(entries) != 1 || entries[0].ID != lastID || entries[0].Values["body"] != `{"id":1}` {
+		t.Errorf("XRange() = %v, want only the last entry %s", entries, lastID)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+type client struct {
+	dialer *websocket.Dialer
+}
+
+// handshakeHeaderKeys are generated by the dialer itself for every handshake, forwarding the client values
+// would duplicate them.
+var handshakeHeaderKeys = []string{
+	"Host",
+	"Upgrade",
+	"Connection",
+	"Sec-Websocket-Key",
+	"Sec-Websocket-Version",
+	"Sec-Websocket-Extensions",
+	"Sec-Websocket-Protocol",
+}
+
+func NewClient() app.WebSocketClient {
+	return client{
+		dialer: &websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: 30 * time.Second,
+			ReadBufferSize:   4096,
+			WriteBufferSize:  4096,
+		},
+	}
+}
+
+func (c client) Dial(ctx context.Context, endpoint *vo.EndpointConfig, parent *vo.EndpointRequest,
+	request *vo.HTTPBackendRequest) (app.WebSocketConn, error) {
+	url := c.buildURL(request)
+
+	dialer := *c.dialer
+	dialer.Subprotocols = c.buildSubprotocols(request)
+
+	engine, response, err := dialer.DialContext(ctx, url, c.buildHeader(endpoint.RequestClient(), parent, request))
+	if checker.NonNil(response) && checker.NonNil(response.Body) {
+		response.Body.Close()
+	}
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "websocket failed: op=dial url=%s", url)
+	}
+
+	return newConn(engine), nil
+}
+
+// buildURL keeps the hosts configured as http/https, as the other HTTP backends, switching to the equivalent
+// WebSocket scheme.
+func (c client) buildURL(request *vo.HTTPBackendRequest) string {
+	url := request.URL()
+	if !request.Query().IsEmpty() {
+		url += "?" + request.Query().Encode()
+	}
+
+	if strings.HasPrefix(url, "https://") {
+		return "wss://" + strings.TrimPrefix(url, "https://")
+	} else if strings.HasPrefix(url, "http://") {
+		return "ws://" + strings.TrimPrefix(url, "http://")
+	}
+	return url
+}
+
+func (c client) buildSubprotocols(request *vo.HTTPBackendRequest) []string {
+	var subprotocols []string
+	for _, value := range request.Header().GetAll("Sec-Websocket-Protocol") {
+		for _, subprotocol := range strings.Split(value, ",") {
+			if subprotocol = strings.TrimSpace(subprotocol); checker.IsNotEmpty(subprotocol) {
+				subprotocols = append(subprotocols, subprotocol)
+			}
+		}
+	}
+	return subprotocols
+}
+
+func (c client) buildHeader(clientCfg *vo.RequestClientConfig, parent *vo.EndpointRequest,
+	request *vo.HTTPBackendRequest) http.Header {
+	header := http.Header(request.Header().Copy())
+	for _, key := range handshakeHeaderKeys {
+		header.Del(key)
+	}
+
+	if checker.NonNil(clientCfg) && clientCfg.IP().HasPropagateRequest() {
+		header.Set(clientCfg.IP().Propagate().Request(), parent.ClientIP())
+	}
+	if checker.NonNil(clientCfg) && checker.NonNil(clientCfg.RequestID()) && clientCfg.RequestID().HasPropagateRequest() {
+		header.Set(clientCfg.RequestID().Propagate().Request(), parent.ID())
+	}
+
+	return header
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2024 Tech4Works
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package websocket
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/tech4works/checker"
+	"github.com/tech4works/errors"
+
+	"github.com/tech4works/gopen-gateway/internal/app"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/enum"
+	"github.com/tech4works/gopen-gateway/internal/domain/model/vo"
+)
+
+const closeWriteTimeout = 5 * time.Second
+
+type conn struct {
+	engine *websocket.Conn
+	mutex  *sync.Mutex
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	// The origin is validated by the security-cors interceptor before the upgrade.
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+	// Keeps the handshake failure to the caller, which writes the response through the gateway context.
+	Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {},
+}
+
+// Upgrade switches the client connection to the WebSocket protocol, answering with the subprotocol already
+// negotiated with the backend, if any.
+func Upgrade(w http.ResponseWriter, r *http.Request, subprotocol string) (app.WebSocketConn, error) {
+	var header http.Header
+	if checker.IsNotEmpty(subprotocol) {
+		header = http.Header{"Sec-Websocket-Protocol": []string{subprotocol}}
+	}
+
+	engine, err := upgrader.Upgrade(w, r, header)
+	if checker.NonNil(err) {
+		return nil, errors.Inheritf(err, "websocket failed: op=upgrade")
+	}
+
+	return newConn(engine), nil
+}
+
+func newConn(engine *websocket.Conn) app.WebSocketConn {
+	return &conn{
+		engine: engine,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+func (c *conn) ReadMessage() (*vo.WebSocketMessage, error) {
+	for {
+		messageType, data, err := c.engine.ReadMessage()
+		if checker.NonNil(err) {
+			return nil, err
+		}
+
+		switch messageType {
+		case websocket.TextMessage:
+			return vo.NewWebSocketMessage(enum.WebSocketMessageTypeText, data), nil
+		case websocket.BinaryMessage:
+			return vo.NewWebSocketMessage(enum.WebSocketMessageTypeBinary, data), nil
+		}
+	}
+}
+
+func (c *conn) WriteMessage(message *vo.WebSocketMessage) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	messageType := websocket.BinaryMessage
+	if message.IsText() {
+		messageType = websocket.TextMessage
+	}
+	return c.engine.WriteMessage(messageType, message.Bytes())
+}
+
+func (c *conn) Subprotocol() string {
+	return c.engine.Subprotocol()
+}
+
+// Close sends the normal closure frame before closing the underlying connection, so the peer sees a clean
+// shutdown instead of an abnormal one.
+func (c *conn) Close() error {
+	c.mutex.Lock()
+	deadline := time.Now().Add(closeWriteTimeout)
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = c.engine.WriteControl(websocket.CloseMessage, closeMessage, deadline)
+	c.mutex.Unlock()
+
+	return c.engine.Close()
+}